@@ -0,0 +1,176 @@
+package alertdefinitions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	actionCreate    = "create"
+	actionUpdate    = "update"
+	actionDelete    = "delete"
+	actionUnchanged = "unchanged"
+)
+
+var errApplyFailed = errors.New("failed to apply some alert definitions")
+
+// definitionPlan is the change planned for a single alert definition
+type definitionPlan struct {
+	Action  string
+	Name    string
+	ID      string
+	Source  string
+	current *AlertDefinition
+	desired *AlertDefinition
+}
+
+// toYAML renders a definition the way it is written in the files
+func toYAML(definition *AlertDefinition) []string {
+	if definition == nil {
+		return nil
+	}
+	var content strings.Builder
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(definition); err != nil {
+		return []string{err.Error()}
+	}
+	_ = encoder.Close()
+	return strings.Split(strings.TrimSuffix(content.String(), "\n"), "\n")
+}
+
+// plan matches the desired definitions to the live ones by name. Live definitions
+// missing from the files are only deleted with --prune.
+func (c *Client) plan(desired []sourcedDefinition, live []AlertDefinition) []*definitionPlan {
+	byName := make(map[string]*AlertDefinition, len(live))
+	for i := range live {
+		live[i].normalize()
+		byName[live[i].Name] = &live[i]
+	}
+
+	var plans []*definitionPlan
+	matched := make(map[string]bool)
+	for i := range desired {
+		definition := &desired[i].AlertDefinition
+		plan := &definitionPlan{Name: definition.Name, Source: desired[i].source, desired: definition}
+
+		current, ok := byName[definition.Name]
+		switch {
+		case !ok:
+			plan.Action = actionCreate
+		default:
+			matched[definition.Name] = true
+			plan.ID = current.ID
+			plan.current = current
+			definition.ID = current.ID
+
+			plan.Action = actionUnchanged
+			if strings.Join(toYAML(current), "\n") != strings.Join(toYAML(definition), "\n") {
+				plan.Action = actionUpdate
+			}
+		}
+		plans = append(plans, plan)
+	}
+
+	if c.opts.Prune {
+		for i := range live {
+			if !matched[live[i].Name] {
+				plans = append(plans, &definitionPlan{Action: actionDelete, Name: live[i].Name, ID: live[i].ID, current: &live[i]})
+			}
+		}
+	}
+
+	return plans
+}
+
+func (c *Client) printPlan(plans []*definitionPlan) {
+	counts := make(map[string]int)
+	color := shared.IsTerminal(c.output)
+
+	for _, p := range plans {
+		counts[p.Action]++
+		switch p.Action {
+		case actionCreate:
+			_, _ = fmt.Fprintf(c.output, "+ create alert definition %q (%s)\n", p.Name, p.Source)
+		case actionUpdate:
+			_, _ = fmt.Fprintf(c.output, "~ update alert definition %q (%s)\n", p.Name, p.ID)
+		case actionDelete:
+			_, _ = fmt.Fprintf(c.output, "- delete alert definition %q (%s)\n", p.Name, p.ID)
+		default:
+			continue
+		}
+
+		if c.opts.Diff {
+			shared.WriteDiff(c.output, shared.DiffLines(toYAML(p.current), toYAML(p.desired)), color)
+		}
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Plan: %d to create, %d to update, %d to delete, %d unchanged\n",
+		counts[actionCreate], counts[actionUpdate], counts[actionDelete], counts[actionUnchanged])
+}
+
+// Apply reconciles the alert definitions of the organization with the files
+func (c *Client) Apply(ctx context.Context) error {
+	desired, err := loadDefinitions(c.opts.Paths, c.output)
+	if err != nil {
+		return err
+	}
+
+	live, err := c.fetchDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	plans := c.plan(desired, live)
+	c.printPlan(plans)
+
+	pending := 0
+	for _, p := range plans {
+		if p.Action != actionUnchanged {
+			pending++
+		}
+	}
+	if pending == 0 || c.opts.PlanOnly {
+		return nil
+	}
+
+	if !c.opts.AutoApprove && !c.opts.DryRun {
+		if err := shared.Approve(c.input, c.prompts, "Do you want to apply these changes?"); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for _, p := range plans {
+		var err error
+		switch p.Action {
+		case actionCreate:
+			err = c.send(ctx, "POST", p.desired)
+		case actionUpdate:
+			err = c.send(ctx, "PUT", p.desired)
+		case actionDelete:
+			err = c.send(ctx, "DELETE", p.current)
+		default:
+			continue
+		}
+
+		if err != nil {
+			failed++
+			_, _ = fmt.Fprintf(c.output, "Failed to %s alert definition %q: %v\n", p.Action, p.Name, err)
+			continue
+		}
+		if !c.opts.DryRun {
+			_, _ = fmt.Fprintf(c.output, "Alert definition %q %sd\n", p.Name, p.Action)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errApplyFailed, failed, pending)
+	}
+	return nil
+}
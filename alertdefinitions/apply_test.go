@@ -0,0 +1,151 @@
+package alertdefinitions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+// desiredDefinitions updates High CPU, keeps Disk full, creates Memory and
+// leaves Legacy out
+const desiredDefinitions = `alertDefinitions:
+  - name: High CPU
+    severity: critical
+    condition:
+      metric: system.cpu.utilization
+      aggregation: avg
+      operator: ">"
+      threshold: 90
+      duration: 300s
+      entityType: Host
+    actions:
+      - type: slack
+        configurationIds: [n-1]
+  - name: Disk full
+    severity: CRITICAL
+    condition:
+      metric: system.disk.utilization
+      aggregation: MAX
+      operator: ">="
+      threshold: 95
+  - name: Memory
+    severity: WARNING
+    condition:
+      metric: system.memory.utilization
+      aggregation: AVG
+      operator: ">"
+      threshold: 85
+`
+
+func newApplyClient(t *testing.T, opts *Options) (*Client, *definitionStore) {
+	live := make([]AlertDefinition, len(liveDefinitions))
+	copy(live, liveDefinitions)
+	server, store := newDefinitionServer(t, live)
+
+	opts.Paths = []string{writeTestFile(t, t.TempDir(), "alerts.yaml", desiredDefinitions)}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	return client, store
+}
+
+func TestApplyPlan(t *testing.T) {
+	opts := NewOptions()
+	opts.PlanOnly = true
+	opts.Diff = true
+	client, store := newApplyClient(t, opts)
+
+	require.NoError(t, client.Apply(context.Background()))
+	require.Empty(t, store.requests)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, `~ update alert definition "High CPU" (def-2)
+  name: High CPU
+- severity: WARNING
++ severity: CRITICAL
+  enabled: true
+  condition:
+    metric: system.cpu.utilization
+    aggregation: AVG
+    operator: '>'
+-   threshold: 80
++   threshold: 90
+`)
+	require.Contains(t, output, `+ create alert definition "Memory" (`+opts.Paths[0]+")\n")
+	require.NotContains(t, output, "Disk full")
+	require.NotContains(t, output, "Legacy")
+	require.True(t, strings.HasSuffix(output, "Plan: 1 to create, 1 to update, 0 to delete, 1 unchanged\n"))
+}
+
+func TestApply(t *testing.T) {
+	opts := NewOptions()
+	opts.AutoApprove = true
+	opts.Prune = true
+	client, store := newApplyClient(t, opts)
+
+	require.NoError(t, client.Apply(context.Background()))
+	require.Equal(t, []string{
+		"PUT /v1/alertdefinitions/def-2",
+		"POST /v1/alertdefinitions",
+		"DELETE /v1/alertdefinitions/def-3",
+	}, store.requests)
+
+	require.Len(t, store.updated, 1)
+	require.Equal(t, "def-2", store.updated[0].ID)
+	require.Equal(t, "CRITICAL", store.updated[0].Severity)
+	require.Equal(t, float64(90), store.updated[0].Condition.Threshold)
+
+	require.Len(t, store.created, 1)
+	require.Equal(t, "Memory", store.created[0].Name)
+	require.Empty(t, store.created[0].ID)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, `- delete alert definition "Legacy" (def-3)`)
+	require.True(t, strings.HasSuffix(output, `Plan: 1 to create, 1 to update, 1 to delete, 1 unchanged
+Alert definition "High CPU" updated
+Alert definition "Memory" created
+Alert definition "Legacy" deleted
+`))
+}
+
+func TestApplyRequiresApproval(t *testing.T) {
+	opts := NewOptions()
+	client, store := newApplyClient(t, opts)
+
+	require.ErrorIs(t, client.Apply(context.Background()), shared.ErrNotApproved)
+	require.Empty(t, store.requests)
+	require.NotContains(t, testutil.ReadOutput(t, client.output), "use --auto-approve")
+}
+
+func TestApplyDryRun(t *testing.T) {
+	opts := NewOptions()
+	opts.DryRun = true
+	client, store := newApplyClient(t, opts)
+
+	require.NoError(t, client.Apply(context.Background()))
+	require.Empty(t, store.requests)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "PUT "+client.opts.APIURL+"/v1/alertdefinitions/def-2\n")
+	require.Contains(t, output, "POST "+client.opts.APIURL+"/v1/alertdefinitions\n")
+	require.NotContains(t, output, "DELETE")
+}
+
+func TestApplyInvalidFiles(t *testing.T) {
+	opts := NewOptions()
+	opts.AutoApprove = true
+	client, store := newApplyClient(t, opts)
+	opts.Paths = []string{writeTestFile(t, t.TempDir(), "bad.yaml", "alertDefinitions:\n  - name: Bad\n")}
+
+	require.ErrorIs(t, client.Apply(context.Background()), errValidationFailed)
+	require.Empty(t, store.requests)
+}
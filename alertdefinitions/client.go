@@ -0,0 +1,311 @@
+// Package alertdefinitions provides a client for exporting alert definitions from
+// the SWO API and reconciling them with definitions kept in files.
+package alertdefinitions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/solarwinds/swo-cli/shared"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultPageSize for retrieving list of alert definitions
+	DefaultPageSize = 100
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+)
+
+// Client is an alert definitions client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	input      *os.File
+	output     *os.File
+	prompts    *os.File
+}
+
+// Condition is the metric condition that triggers an alert
+type Condition struct {
+	Metric      string   `json:"metric" yaml:"metric"`
+	Aggregation string   `json:"aggregation" yaml:"aggregation"`
+	Operator    string   `json:"operator" yaml:"operator"`
+	Threshold   float64  `json:"threshold" yaml:"threshold"`
+	Duration    string   `json:"duration,omitempty" yaml:"duration,omitempty"`
+	EntityType  string   `json:"entityType,omitempty" yaml:"entityType,omitempty"`
+	EntityIDs   []string `json:"entityIds,omitempty" yaml:"entityIds,omitempty"`
+}
+
+// Action is a notification sent when the alert triggers
+type Action struct {
+	Type             string   `json:"type" yaml:"type"`
+	ConfigurationIDs []string `json:"configurationIds" yaml:"configurationIds"`
+}
+
+// AlertDefinition describes when an alert is triggered and who is notified. The
+// ID is not part of the files, definitions are matched by name so that the same
+// files can be applied to several organizations.
+type AlertDefinition struct {
+	ID          string    `json:"id,omitempty" yaml:"-"`
+	Name        string    `json:"name" yaml:"name"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Severity    string    `json:"severity" yaml:"severity"`
+	Enabled     *bool     `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Condition   Condition `json:"condition" yaml:"condition"`
+	Actions     []Action  `json:"actions,omitempty" yaml:"actions,omitempty"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type listDefinitionsResponse struct {
+	AlertDefinitions []AlertDefinition `json:"alertDefinitions"`
+	pageInfo         `json:"pageInfo"`
+}
+
+// NewClient creates a new alert definitions client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		input:      os.Stdin,
+		output:     os.Stdout,
+		prompts:    os.Stderr,
+	}, nil
+}
+
+func (c *Client) prepareRequest(ctx context.Context, method string, endpoint string, params url.Values, body interface{}) (*http.Request, error) {
+	requestURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	requestURL.RawQuery = params.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal alert definition: %w", err)
+		}
+		reader = bytes.NewBuffer(jsonData)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
+	request.Header.Add("Accept", "application/json")
+	if body != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+
+	return request, nil
+}
+
+func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+	if c.opts.Name != "" {
+		params.Add("name", c.opts.Name)
+	}
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/alertdefinitions")
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+// fetchDefinitions retrieves all alert definitions, sorted by name
+func (c *Client) fetchDefinitions(ctx context.Context) ([]AlertDefinition, error) {
+	definitions := []AlertDefinition{}
+	var nextPage string
+
+	for {
+		request, err := c.prepareListRequest(ctx, nextPage)
+		if err != nil {
+			return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+		}
+
+		content, err := c.doRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		var response listDefinitionsResponse
+		if err := json.Unmarshal(content, &response); err != nil {
+			return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+		}
+		definitions = append(definitions, response.AlertDefinitions...)
+
+		if response.NextPage == "" {
+			break
+		}
+		nextPage = response.NextPage
+	}
+
+	sort.SliceStable(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+	return definitions, nil
+}
+
+// send creates, updates or deletes an alert definition. In dry run mode the
+// request is printed instead.
+func (c *Client) send(ctx context.Context, method string, definition *AlertDefinition) error {
+	var endpoint string
+	var err error
+	var body interface{}
+
+	switch method {
+	case "POST":
+		endpoint, err = url.JoinPath(c.opts.APIURL, "v1/alertdefinitions")
+		body = definition
+	case "PUT":
+		endpoint, err = url.JoinPath(c.opts.APIURL, "v1/alertdefinitions", definition.ID)
+		body = definition
+	default:
+		endpoint, err = url.JoinPath(c.opts.APIURL, "v1/alertdefinitions", definition.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	request, err := c.prepareRequest(ctx, method, endpoint, url.Values{}, body)
+	if err != nil {
+		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	if c.opts.DryRun {
+		return shared.PrintDryRunRequest(c.output, request)
+	}
+
+	_, err = c.doRequest(request)
+	if errors.Is(err, ErrNoContent) {
+		return nil
+	}
+	return err
+}
+
+// Export writes all alert definitions as a definitions file to the output file
+// or standard output
+func (c *Client) Export(ctx context.Context) error {
+	definitions, err := c.fetchDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	// IDs differ between organizations, the files identify definitions by name
+	for i := range definitions {
+		definitions[i].ID = ""
+		definitions[i].normalize()
+	}
+
+	if c.opts.Out == "" {
+		return c.writeDefinitions(c.output, definitions)
+	}
+
+	f, err := os.Create(c.opts.Out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", c.opts.Out, err)
+	}
+
+	err = c.writeDefinitions(f, definitions)
+	// Writes may only fail when the file is closed, e.g. on a full disk
+	if closeErr := f.Close(); closeErr != nil && err == nil {
+		err = fmt.Errorf("failed to write %s: %w", c.opts.Out, closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Exported %d alert definitions to %s\n", len(definitions), c.opts.Out)
+
+	return nil
+}
+
+// writeDefinitions encodes the definitions file in the selected output format
+func (c *Client) writeDefinitions(w io.Writer, definitions []AlertDefinition) error {
+	var err error
+	file := definitionsFile{AlertDefinitions: definitions}
+	if c.opts.Format == FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(file)
+	} else {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		err = encoder.Encode(file)
+		if closeErr := encoder.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write alert definitions: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks the definition files against the schema without calling the API
+func (c *Client) Validate() error {
+	definitions, err := loadDefinitions(c.opts.Paths, c.output)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.output, "%d alert definitions are valid\n", len(definitions))
+	return nil
+}
@@ -0,0 +1,199 @@
+package alertdefinitions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// definitionStore serves alert definitions in pages of one and records changes
+type definitionStore struct {
+	mu          sync.Mutex
+	definitions []AlertDefinition
+	requests    []string
+	created     []AlertDefinition
+	updated     []AlertDefinition
+}
+
+func newDefinitionServer(t *testing.T, definitions []AlertDefinition) (*httptest.Server, *definitionStore) {
+	store := &definitionStore{definitions: definitions}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		if r.Method != "GET" {
+			store.requests = append(store.requests, r.Method+" "+r.URL.Path)
+		}
+
+		switch r.Method {
+		case "GET":
+			require.Equal(t, "/v1/alertdefinitions", r.URL.Path)
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			response := listDefinitionsResponse{AlertDefinitions: []AlertDefinition{}}
+			if page < len(store.definitions) {
+				response.AlertDefinitions = store.definitions[page : page+1]
+			}
+			if page+1 < len(store.definitions) {
+				response.NextPage = "/v1/alertdefinitions?page=" + strconv.Itoa(page+1)
+			}
+			testutil.WriteJSON(t, w, response)
+		case "POST", "PUT":
+			var definition AlertDefinition
+			if err := json.NewDecoder(r.Body).Decode(&definition); err != nil {
+				t.Errorf("Failed to decode definition: %v", err)
+			}
+			if r.Method == "POST" {
+				store.created = append(store.created, definition)
+				testutil.WriteJSON(t, w, map[string]string{"id": "def-new"})
+			} else {
+				store.updated = append(store.updated, definition)
+				testutil.WriteJSON(t, w, definition)
+			}
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, store
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+var liveDefinitions = []AlertDefinition{
+	{
+		ID:       "def-2",
+		Name:     "High CPU",
+		Severity: "WARNING",
+		Enabled:  boolPtr(true),
+		Condition: Condition{
+			Metric: "system.cpu.utilization", Aggregation: "AVG", Operator: ">", Threshold: 80, Duration: "5m", EntityType: "Host",
+		},
+		Actions: []Action{{Type: "slack", ConfigurationIDs: []string{"n-1"}}},
+	},
+	{
+		ID:       "def-1",
+		Name:     "Disk full",
+		Severity: "CRITICAL",
+		Condition: Condition{
+			Metric: "system.disk.utilization", Aggregation: "max", Operator: ">=", Threshold: 95,
+		},
+	},
+	{
+		ID:       "def-3",
+		Name:     "Legacy",
+		Severity: "INFO",
+		Condition: Condition{
+			Metric: "legacy.errors", Aggregation: "COUNT", Operator: ">", Threshold: 0,
+		},
+	},
+}
+
+func TestExport(t *testing.T) {
+	server, _ := newDefinitionServer(t, liveDefinitions)
+
+	opts := NewOptions()
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.Export(context.Background()))
+	require.Equal(t, `alertDefinitions:
+  - name: Disk full
+    severity: CRITICAL
+    enabled: true
+    condition:
+      metric: system.disk.utilization
+      aggregation: MAX
+      operator: '>='
+      threshold: 95
+  - name: High CPU
+    severity: WARNING
+    enabled: true
+    condition:
+      metric: system.cpu.utilization
+      aggregation: AVG
+      operator: '>'
+      threshold: 80
+      duration: 5m
+      entityType: Host
+    actions:
+      - type: slack
+        configurationIds:
+          - n-1
+  - name: Legacy
+    severity: INFO
+    enabled: true
+    condition:
+      metric: legacy.errors
+      aggregation: COUNT
+      operator: '>'
+      threshold: 0
+`, testutil.ReadOutput(t, client.output))
+}
+
+func TestExportWriteError(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full is not available")
+	}
+	server, _ := newDefinitionServer(t, liveDefinitions)
+
+	opts := NewOptions()
+	opts.Out = "/dev/full"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.Error(t, client.Export(context.Background()))
+	require.NotContains(t, testutil.ReadOutput(t, client.output), "Exported")
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	server, _ := newDefinitionServer(t, liveDefinitions)
+
+	for _, format := range []string{FormatYAML, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			path := t.TempDir() + "/alerts." + format
+
+			opts := NewOptions()
+			opts.Format = format
+			opts.Out = path
+			opts.Token = "test-token"
+			opts.APIURL = server.URL
+			client, err := NewClient(opts)
+			require.NoError(t, err)
+			client.output = testutil.TempFile(t)
+			client.input = testutil.TempFile(t)
+			client.prompts = testutil.TempFile(t)
+
+			require.NoError(t, client.Export(context.Background()))
+			require.Equal(t, "Exported 3 alert definitions to "+path+"\n", testutil.ReadOutput(t, client.output))
+
+			var out strings.Builder
+			definitions, err := loadDefinitions([]string{path}, &out)
+			require.NoError(t, err)
+			require.Len(t, definitions, 3)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package alertdefinitions
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+// NewAlertDefinitionsCommand creates the alert-definitions command
+func NewAlertDefinitionsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "alert-definitions",
+		Usage: "Manage alert definitions as code",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "export",
+				Usage:  "Export alert definitions in the file format read by apply",
+				Action: runExport,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "name",
+						Aliases: []string{"n"},
+						Usage:   "Filter alert definitions by name",
+					},
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"o"},
+						Usage:   "Output format: yaml or json",
+						Value:   FormatYAML,
+					},
+					&cli.StringFlag{
+						Name:  "out",
+						Usage: "Path of the exported file, standard output if not set",
+					},
+				},
+			},
+			{
+				Name:  "apply",
+				Usage: "Create, update and optionally delete alert definitions to match the files",
+				Description: `Alert definitions are matched by name. Definitions that exist only in the
+organization are kept unless --prune is set. Use --plan to only show the changes
+and the global --dry-run to print the API requests instead of sending them.`,
+				Action: runApply,
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "file",
+						Aliases:  []string{"f"},
+						Usage:    "Definitions file or directory of YAML and JSON files (required, can be specified multiple times)",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "plan",
+						Usage: "Only show the planned changes",
+					},
+					&cli.BoolFlag{
+						Name:  "diff",
+						Usage: "Show the changes of every definition as a diff",
+					},
+					&cli.BoolFlag{
+						Name:  "prune",
+						Usage: "Delete alert definitions that are not in the files",
+					},
+					&cli.BoolFlag{
+						Name:  "auto-approve",
+						Usage: "Apply the plan without asking for confirmation",
+					},
+				},
+			},
+			{
+				Name:      "validate",
+				Usage:     "Validate definition files against the schema without calling the API",
+				ArgsUsage: "[FILE|DIR...]",
+				Action:    runValidate,
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "Definitions file or directory of YAML and JSON files (can be specified multiple times)",
+					},
+				},
+			},
+		},
+	}
+}
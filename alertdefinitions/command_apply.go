@@ -0,0 +1,32 @@
+package alertdefinitions
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func runApply(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Paths = ctx.StringSlice("file")
+	opts.PlanOnly = ctx.Bool("plan")
+	opts.Diff = ctx.Bool("diff")
+	opts.Prune = ctx.Bool("prune")
+	opts.AutoApprove = ctx.Bool("auto-approve")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForApply(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Apply(context.Background())
+}
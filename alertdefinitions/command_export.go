@@ -0,0 +1,29 @@
+package alertdefinitions
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func runExport(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Name = ctx.String("name")
+	opts.Format = ctx.String("format")
+	opts.Out = ctx.String("out")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForExport(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Export(context.Background())
+}
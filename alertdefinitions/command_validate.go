@@ -0,0 +1,23 @@
+package alertdefinitions
+
+import (
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func runValidate(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Paths = append(ctx.StringSlice("file"), ctx.Args().Slice()...)
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+
+	if err := opts.ValidateForApply(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Validate()
+}
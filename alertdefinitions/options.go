@@ -0,0 +1,63 @@
+package alertdefinitions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// FormatYAML prints alert definitions as a YAML definitions file
+	FormatYAML = "yaml"
+	// FormatJSON prints alert definitions as JSON
+	FormatJSON = "json"
+)
+
+var (
+	errMissingPath   = errors.New("file or directory is required")
+	errInvalidFormat = errors.New("invalid output format, expected yaml or json")
+)
+
+// Options represents the command line options for the alert-definitions command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	Name               string
+	Format             string
+	Out                string
+	Paths              []string
+	PlanOnly           bool
+	Diff               bool
+	Prune              bool
+	AutoApprove        bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{
+		Format: FormatYAML,
+	}
+}
+
+// ValidateForExport validates options for export operation
+func (o *Options) ValidateForExport() error {
+	o.Format = strings.ToLower(o.Format)
+	if o.Format != FormatYAML && o.Format != FormatJSON {
+		return fmt.Errorf("%w: %s", errInvalidFormat, o.Format)
+	}
+	return nil
+}
+
+// ValidateForApply validates options for apply and validate operations
+func (o *Options) ValidateForApply() error {
+	if len(o.Paths) == 0 {
+		return errMissingPath
+	}
+	for _, path := range o.Paths {
+		if strings.TrimSpace(path) == "" {
+			return errMissingPath
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package alertdefinitions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateForExport(t *testing.T) {
+	opts := NewOptions()
+	require.NoError(t, opts.ValidateForExport())
+
+	opts.Format = "JSON"
+	require.NoError(t, opts.ValidateForExport())
+	require.Equal(t, FormatJSON, opts.Format)
+
+	opts.Format = "toml"
+	require.ErrorIs(t, opts.ValidateForExport(), errInvalidFormat)
+}
+
+func TestValidateForApply(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingPath, opts.ValidateForApply())
+
+	opts.Paths = []string{" "}
+	require.Equal(t, errMissingPath, opts.ValidateForApply())
+
+	opts.Paths = []string{"alerts/"}
+	require.NoError(t, opts.ValidateForApply())
+}
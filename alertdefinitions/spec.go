@@ -0,0 +1,221 @@
+package alertdefinitions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var (
+	errValidationFailed = errors.New("alert definitions are invalid")
+
+	severities   = []string{"INFO", "WARNING", "CRITICAL"}
+	aggregations = []string{"AVG", "SUM", "MIN", "MAX", "COUNT", "LAST"}
+	operators    = []string{">", ">=", "<", "<=", "==", "!="}
+	actionTypes  = []string{"email", "webhook", "slack", "pagerduty"}
+)
+
+// definitionsFile is the format written by export and read by apply and validate
+//
+//	alertDefinitions:
+//	  - name: High CPU
+//	    severity: CRITICAL
+//	    condition:
+//	      metric: system.cpu.utilization
+//	      aggregation: AVG
+//	      operator: ">"
+//	      threshold: 90
+//	      duration: 5m
+//	      entityType: Host
+//	    actions:
+//	      - type: slack
+//	        configurationIds: [n-123]
+type definitionsFile struct {
+	AlertDefinitions []AlertDefinition `json:"alertDefinitions" yaml:"alertDefinitions"`
+}
+
+// sourcedDefinition remembers which file a definition was read from
+type sourcedDefinition struct {
+	AlertDefinition
+	source string
+}
+
+// definitionFiles expands the paths into the YAML and JSON files they contain,
+// in a stable order
+func definitionFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// decodeFile reads the definitions of a file, rejecting unknown fields so that
+// typos are not silently ignored. JSON is a subset of YAML, so both are accepted.
+func decodeFile(path string) ([]AlertDefinition, error) {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var file definitionsFile
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error while unmarshaling %s: %w", path, err)
+	}
+
+	return file.AlertDefinitions, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize fills in defaults and canonical casing, so that definitions compare
+// equal regardless of how they were written
+func (d *AlertDefinition) normalize() {
+	d.Name = strings.TrimSpace(d.Name)
+	d.Severity = strings.ToUpper(strings.TrimSpace(d.Severity))
+	d.Condition.Aggregation = strings.ToUpper(strings.TrimSpace(d.Condition.Aggregation))
+	if d.Enabled == nil {
+		enabled := true
+		d.Enabled = &enabled
+	}
+	if d.Condition.Duration != "" {
+		if duration, err := shared.ParseDuration(d.Condition.Duration); err == nil {
+			d.Condition.Duration = shared.FormatDuration(duration)
+		}
+	}
+	for i := range d.Actions {
+		d.Actions[i].Type = strings.ToLower(strings.TrimSpace(d.Actions[i].Type))
+	}
+}
+
+// validate checks a normalized definition against the schema and returns every
+// problem found
+func (d *AlertDefinition) validate() []string {
+	var problems []string
+	if d.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if !contains(severities, d.Severity) {
+		problems = append(problems, fmt.Sprintf("severity must be one of %s, got %q", strings.Join(severities, ", "), d.Severity))
+	}
+
+	condition := d.Condition
+	if strings.TrimSpace(condition.Metric) == "" {
+		problems = append(problems, "condition.metric is required")
+	}
+	if !contains(aggregations, condition.Aggregation) {
+		problems = append(problems, fmt.Sprintf("condition.aggregation must be one of %s, got %q", strings.Join(aggregations, ", "), condition.Aggregation))
+	}
+	if !contains(operators, condition.Operator) {
+		problems = append(problems, fmt.Sprintf("condition.operator must be one of %s, got %q", strings.Join(operators, " "), condition.Operator))
+	}
+	if condition.Duration != "" {
+		if duration, err := shared.ParseDuration(condition.Duration); err != nil || duration <= 0 {
+			problems = append(problems, fmt.Sprintf("condition.duration must be a positive duration like 5m, got %q", condition.Duration))
+		}
+	}
+
+	for i, action := range d.Actions {
+		if !contains(actionTypes, action.Type) {
+			problems = append(problems, fmt.Sprintf("actions[%d].type must be one of %s, got %q", i, strings.Join(actionTypes, ", "), action.Type))
+		}
+		if len(action.ConfigurationIDs) == 0 {
+			problems = append(problems, fmt.Sprintf("actions[%d].configurationIds is required", i))
+		}
+	}
+
+	return problems
+}
+
+// loadDefinitions reads, normalizes and validates the definitions of all files.
+// Every problem is written to w before errValidationFailed is returned.
+func loadDefinitions(paths []string, w io.Writer) ([]sourcedDefinition, error) {
+	files, err := definitionFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []sourcedDefinition
+	sources := make(map[string]string)
+	invalid := 0
+
+	for _, file := range files {
+		decoded, err := decodeFile(file)
+		if err != nil {
+			_, _ = fmt.Fprintln(w, err)
+			invalid++
+			continue
+		}
+
+		for i := range decoded {
+			definition := decoded[i]
+			definition.ID = ""
+			definition.normalize()
+
+			location := fmt.Sprintf("%s: alertDefinitions[%d]", file, i)
+			if definition.Name != "" {
+				location += fmt.Sprintf(" %q", definition.Name)
+			}
+
+			problems := definition.validate()
+			if other, ok := sources[definition.Name]; ok && definition.Name != "" {
+				problems = append(problems, fmt.Sprintf("name is already used in %s", other))
+			}
+			sources[definition.Name] = file
+
+			for _, problem := range problems {
+				_, _ = fmt.Fprintf(w, "%s: %s\n", location, problem)
+			}
+			if len(problems) > 0 {
+				invalid++
+				continue
+			}
+
+			definitions = append(definitions, sourcedDefinition{AlertDefinition: definition, source: file})
+		}
+	}
+
+	if invalid > 0 {
+		return nil, fmt.Errorf("%w: %d invalid definitions or files", errValidationFailed, invalid)
+	}
+
+	return definitions, nil
+}
@@ -0,0 +1,107 @@
+package alertdefinitions
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+const cpuDefinition = `alertDefinitions:
+  - name: High CPU
+    severity: critical
+    condition:
+      metric: system.cpu.utilization
+      aggregation: avg
+      operator: ">"
+      threshold: 90
+      duration: 300s
+      entityType: Host
+    actions:
+      - type: Slack
+        configurationIds: [n-1]
+`
+
+func TestLoadDefinitions(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "cpu.yaml", cpuDefinition)
+	writeTestFile(t, dir, "disk.json", `{"alertDefinitions": [{"name": "Disk full", "severity": "WARNING", "enabled": false,
+		"condition": {"metric": "system.disk.utilization", "aggregation": "MAX", "operator": ">=", "threshold": 95}}]}`)
+	writeTestFile(t, dir, "README.md", "not a definition")
+
+	var out bytes.Buffer
+	definitions, err := loadDefinitions([]string{dir}, &out)
+	require.NoError(t, err)
+	require.Empty(t, out.String())
+	require.Len(t, definitions, 2)
+
+	cpu := definitions[0]
+	require.Equal(t, filepath.Join(dir, "cpu.yaml"), cpu.source)
+	require.Equal(t, "High CPU", cpu.Name)
+	require.Equal(t, "CRITICAL", cpu.Severity)
+	require.True(t, *cpu.Enabled)
+	require.Equal(t, "AVG", cpu.Condition.Aggregation)
+	require.Equal(t, "5m", cpu.Condition.Duration)
+	require.Equal(t, []Action{{Type: "slack", ConfigurationIDs: []string{"n-1"}}}, cpu.Actions)
+
+	disk := definitions[1]
+	require.Equal(t, "Disk full", disk.Name)
+	require.False(t, *disk.Enabled)
+}
+
+func TestLoadDefinitionsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	cpu := writeTestFile(t, dir, "cpu.yaml", cpuDefinition)
+	duplicate := writeTestFile(t, dir, "duplicate.yaml", cpuDefinition)
+	invalid := writeTestFile(t, dir, "invalid.yaml", `alertDefinitions:
+  - severity: major
+    condition:
+      aggregation: median
+      operator: "=>"
+      duration: soon
+    actions:
+      - type: sms
+`)
+	typo := writeTestFile(t, dir, "typo.yaml", `alertDefinitions:
+  - name: Typo
+    severity: INFO
+    condition:
+      metric: m
+      aggregation: AVG
+      operater: ">"
+`)
+
+	var out bytes.Buffer
+	_, err := loadDefinitions([]string{cpu, duplicate, invalid, typo}, &out)
+	require.ErrorIs(t, err, errValidationFailed)
+
+	output := out.String()
+	require.Contains(t, output, duplicate+`: alertDefinitions[0] "High CPU": name is already used in `+cpu+"\n")
+	for _, problem := range []string{
+		"name is required",
+		`severity must be one of INFO, WARNING, CRITICAL, got "MAJOR"`,
+		"condition.metric is required",
+		`condition.aggregation must be one of AVG, SUM, MIN, MAX, COUNT, LAST, got "MEDIAN"`,
+		`condition.operator must be one of > >= < <= == !=, got "=>"`,
+		`condition.duration must be a positive duration like 5m, got "soon"`,
+		`actions[0].type must be one of email, webhook, slack, pagerduty, got "sms"`,
+		"actions[0].configurationIds is required",
+	} {
+		require.Contains(t, output, invalid+": alertDefinitions[0]: "+problem+"\n")
+	}
+	require.Contains(t, output, "field operater not found")
+}
+
+func TestLoadDefinitionsMissingPath(t *testing.T) {
+	var out bytes.Buffer
+	_, err := loadDefinitions([]string{filepath.Join(t.TempDir(), "missing")}, &out)
+	require.Error(t, err)
+}
@@ -0,0 +1,442 @@
+// Package alerts provides a client for listing and handling alerts in the SWO API.
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultPageSize for retrieving list of alerts
+	DefaultPageSize = 100
+
+	actionAcknowledge = "acknowledge"
+	actionResolve     = "resolve"
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+
+	errActionNotPermitted = errors.New("the API does not permit this action for the alert")
+)
+
+// Client is an alerts client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	output     *os.File
+}
+
+// Condition describes what triggered an alert
+type Condition struct {
+	Description string   `json:"description,omitempty"`
+	Metric      string   `json:"metric,omitempty"`
+	Operator    string   `json:"operator,omitempty"`
+	Threshold   *float64 `json:"threshold,omitempty"`
+	Value       *float64 `json:"value,omitempty"`
+}
+
+// AffectedEntity is an entity an alert was triggered for
+type AffectedEntity struct {
+	ID   string `json:"id"`
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Alert is a triggered alert
+type Alert struct {
+	ID                string           `json:"id"`
+	Name              string           `json:"name"`
+	Severity          string           `json:"severity"`
+	State             string           `json:"state"`
+	AlertDefinitionID string           `json:"alertDefinitionId,omitempty"`
+	TriggeredTime     string           `json:"triggeredTime,omitempty"`
+	AcknowledgedTime  string           `json:"acknowledgedTime,omitempty"`
+	AcknowledgedBy    string           `json:"acknowledgedBy,omitempty"`
+	ResolvedTime      string           `json:"resolvedTime,omitempty"`
+	Condition         *Condition       `json:"triggeringCondition,omitempty"`
+	Entities          []AffectedEntity `json:"affectedEntities,omitempty"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type actionResponse struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+	Action string `json:"action"`
+}
+
+type listAlertsResponse struct {
+	Alerts   []Alert `json:"alerts"`
+	pageInfo `json:"pageInfo"`
+}
+
+// NewClient creates a new alerts client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		output:     os.Stdout,
+	}, nil
+}
+
+func (c *Client) prepareRequest(ctx context.Context, method string, endpoint string, params url.Values) (*http.Request, error) {
+	requestURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	requestURL.RawQuery = params.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
+	request.Header.Add("Accept", "application/json")
+
+	return request, nil
+}
+
+func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+	if c.opts.State != "" {
+		params.Add("state", strings.ToUpper(c.opts.State))
+	}
+	if c.opts.Severity != "" {
+		params.Add("severity", strings.ToUpper(c.opts.Severity))
+	}
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/alerts")
+}
+
+func (c *Client) prepareGetRequest(ctx context.Context, id string) (*http.Request, error) {
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, nil, "", "v1/alerts", id)
+}
+
+func (c *Client) prepareActionRequest(ctx context.Context, id string, action string) (*http.Request, error) {
+	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/alerts", id, action)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.prepareRequest(ctx, "POST", endpoint, url.Values{})
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+func (c *Client) getAlerts(ctx context.Context, nextPage string) (*listAlertsResponse, error) {
+	request, err := c.prepareListRequest(ctx, nextPage)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response listAlertsResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &response, nil
+}
+
+// listAlerts calls fn with every page of alerts matching the options
+func (c *Client) listAlerts(ctx context.Context, fn func([]Alert) error) error {
+	var nextPage string
+
+	for {
+		response, err := c.getAlerts(ctx, nextPage)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(response.Alerts); err != nil {
+			return err
+		}
+
+		if response.NextPage == "" {
+			return nil
+		}
+		nextPage = response.NextPage
+	}
+}
+
+func (c *Client) getAlert(ctx context.Context, id string) (*Alert, error) {
+	request, err := c.prepareGetRequest(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var alert Alert
+	if err := json.Unmarshal(content, &alert); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &alert, nil
+}
+
+// postAction acknowledges or resolves an alert. Actions the API does not offer
+// for an alert, e.g. resolving an alert that resolves itself, are reported as
+// not permitted instead of as a plain API error.
+func (c *Client) postAction(ctx context.Context, id string, action string) error {
+	request, err := c.prepareActionRequest(ctx, id, action)
+	if err != nil {
+		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	if c.opts.DryRun {
+		return shared.PrintDryRunRequest(c.output, request)
+	}
+
+	slog.Debug("Sending HTTP request", "method", request.Method, "url", request.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(request) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	content, _ := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	switch {
+	case response.StatusCode >= 200 && response.StatusCode <= 299:
+		return nil
+	case response.StatusCode == http.StatusForbidden, response.StatusCode == http.StatusMethodNotAllowed,
+		response.StatusCode == http.StatusConflict, response.StatusCode == http.StatusNotImplemented:
+		return fmt.Errorf("%w: %s %s: %d, response body: %s", errActionNotPermitted, action, id, response.StatusCode, string(content))
+	default:
+		return fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+}
+
+func formatEntities(entities []AffectedEntity) string {
+	parts := make([]string, len(entities))
+	for i, entity := range entities {
+		parts[i] = entity.ID
+		if entity.Name != "" {
+			parts[i] = entity.Name + " (" + entity.ID + ")"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (c *Client) printAlerts(alerts []Alert) error {
+	for _, alert := range alerts {
+		if c.opts.JSON {
+			jsonData, err := json.Marshal(alert)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(c.output, string(jsonData))
+			continue
+		}
+
+		_, _ = fmt.Fprintf(c.output, "ID: %s, Severity: %s, State: %s, Name: %s", alert.ID, alert.Severity, alert.State, alert.Name)
+		if alert.TriggeredTime != "" {
+			_, _ = fmt.Fprintf(c.output, ", TriggeredTime: %s", alert.TriggeredTime)
+		}
+		if len(alert.Entities) > 0 {
+			_, _ = fmt.Fprintf(c.output, ", Entities: %s", formatEntities(alert.Entities))
+		}
+		_, _ = fmt.Fprintln(c.output)
+	}
+	return nil
+}
+
+func formatCondition(condition *Condition) string {
+	var parts []string
+	if condition.Metric != "" {
+		parts = append(parts, condition.Metric)
+	}
+	if condition.Operator != "" {
+		parts = append(parts, condition.Operator)
+	}
+	if condition.Threshold != nil {
+		parts = append(parts, strconv.FormatFloat(*condition.Threshold, 'f', -1, 64))
+	}
+	text := strings.Join(parts, " ")
+	if condition.Value != nil {
+		text += fmt.Sprintf(" (value: %s)", strconv.FormatFloat(*condition.Value, 'f', -1, 64))
+	}
+	return strings.TrimSpace(text)
+}
+
+func (c *Client) printAlert(alert *Alert) error {
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(alert)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(c.output, "ID: %s\n", alert.ID)
+	_, _ = fmt.Fprintf(c.output, "Name: %s\n", alert.Name)
+	_, _ = fmt.Fprintf(c.output, "Severity: %s\n", alert.Severity)
+	_, _ = fmt.Fprintf(c.output, "State: %s\n", alert.State)
+	if alert.AlertDefinitionID != "" {
+		_, _ = fmt.Fprintf(c.output, "AlertDefinitionID: %s\n", alert.AlertDefinitionID)
+	}
+	if alert.TriggeredTime != "" {
+		_, _ = fmt.Fprintf(c.output, "TriggeredTime: %s\n", alert.TriggeredTime)
+	}
+	if alert.AcknowledgedTime != "" {
+		_, _ = fmt.Fprintf(c.output, "AcknowledgedTime: %s\n", alert.AcknowledgedTime)
+	}
+	if alert.AcknowledgedBy != "" {
+		_, _ = fmt.Fprintf(c.output, "AcknowledgedBy: %s\n", alert.AcknowledgedBy)
+	}
+	if alert.ResolvedTime != "" {
+		_, _ = fmt.Fprintf(c.output, "ResolvedTime: %s\n", alert.ResolvedTime)
+	}
+
+	if alert.Condition != nil {
+		_, _ = fmt.Fprintf(c.output, "Condition:\n")
+		if alert.Condition.Description != "" {
+			_, _ = fmt.Fprintf(c.output, "  %s\n", alert.Condition.Description)
+		}
+		if text := formatCondition(alert.Condition); text != "" {
+			_, _ = fmt.Fprintf(c.output, "  %s\n", text)
+		}
+	}
+
+	if len(alert.Entities) > 0 {
+		_, _ = fmt.Fprintf(c.output, "Entities:\n")
+		for _, entity := range alert.Entities {
+			_, _ = fmt.Fprintf(c.output, "  ID: %s", entity.ID)
+			if entity.Type != "" {
+				_, _ = fmt.Fprintf(c.output, ", Type: %s", entity.Type)
+			}
+			if entity.Name != "" {
+				_, _ = fmt.Fprintf(c.output, ", Name: %s", entity.Name)
+			}
+			_, _ = fmt.Fprintln(c.output)
+		}
+	}
+
+	return nil
+}
+
+// ListAlerts retrieves and displays alerts, optionally filtered by state and severity
+func (c *Client) ListAlerts(ctx context.Context) error {
+	return c.listAlerts(ctx, func(alerts []Alert) error {
+		if err := c.printAlerts(alerts); err != nil {
+			return fmt.Errorf("failed to print result: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetAlert retrieves and displays an alert with its condition and affected entities
+func (c *Client) GetAlert(ctx context.Context) error {
+	alert, err := c.getAlert(ctx, c.opts.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.printAlert(alert)
+}
+
+// AcknowledgeAlert acknowledges an alert
+func (c *Client) AcknowledgeAlert(ctx context.Context) error {
+	return c.runAction(ctx, actionAcknowledge, "acknowledged")
+}
+
+// ResolveAlert resolves an alert
+func (c *Client) ResolveAlert(ctx context.Context) error {
+	return c.runAction(ctx, actionResolve, "resolved")
+}
+
+// runAction posts the action and reports its result the way entities update does
+func (c *Client) runAction(ctx context.Context, action string, done string) error {
+	if err := c.postAction(ctx, c.opts.ID, action); err != nil {
+		return err
+	}
+
+	status := "success"
+	if c.opts.DryRun {
+		status = "dry-run"
+	}
+
+	if !c.opts.JSON {
+		switch status {
+		case "success":
+			_, _ = fmt.Fprintf(c.output, "Alert %s %s successfully\n", c.opts.ID, done)
+		case "dry-run":
+			_, _ = fmt.Fprintf(c.output, "Dry run: alert %s was not %s\n", c.opts.ID, done)
+		}
+		return nil
+	}
+
+	jsonData, err := json.Marshal(actionResponse{Status: status, ID: c.opts.ID, Action: action})
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(c.output, string(jsonData))
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+// alertStore holds the alerts served by the fake API. List responses are paged
+// with one alert per page. Unlisted alerts can only be fetched by ID, like alerts
+// that no longer match the filters of the listing.
+type alertStore struct {
+	mu       sync.Mutex
+	alerts   []Alert
+	unlisted []Alert
+	queries  []string
+	actions  []string
+}
+
+func (s *alertStore) set(alerts []Alert, unlisted ...Alert) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = alerts
+	s.unlisted = unlisted
+}
+
+func newAlertServer(t *testing.T, alerts []Alert) (*httptest.Server, *alertStore) {
+	store := &alertStore{alerts: alerts}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, "/v1/alerts")
+		switch {
+		case r.Method == "GET" && path == "":
+			store.queries = append(store.queries, r.URL.RawQuery)
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			response := listAlertsResponse{Alerts: []Alert{}}
+			if page < len(store.alerts) {
+				response.Alerts = store.alerts[page : page+1]
+			}
+			if page+1 < len(store.alerts) {
+				response.NextPage = "/v1/alerts?page=" + strconv.Itoa(page+1)
+			}
+			testutil.WriteJSON(t, w, response)
+		case r.Method == "GET":
+			for _, alert := range append(store.alerts, store.unlisted...) {
+				if "/"+alert.ID == path {
+					testutil.WriteJSON(t, w, alert)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "POST":
+			store.actions = append(store.actions, path)
+			if strings.HasPrefix(path, "/a-resolved/") {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, store
+}
+
+var testAlerts = []Alert{
+	{
+		ID:                "a-1",
+		Name:              "High CPU",
+		Severity:          "CRITICAL",
+		State:             "ACTIVE",
+		AlertDefinitionID: "def-1",
+		TriggeredTime:     "2024-05-13T10:00:00Z",
+		Condition: &Condition{
+			Description: "CPU above 90% for 5 minutes",
+			Metric:      "system.cpu.utilization",
+			Operator:    ">",
+			Threshold:   floatPtr(90),
+			Value:       floatPtr(97.5),
+		},
+		Entities: []AffectedEntity{{ID: "e-1", Type: "Host", Name: "web-01"}, {ID: "e-2"}},
+	},
+	{
+		ID:            "a-2",
+		Name:          "Disk almost full",
+		Severity:      "WARNING",
+		State:         "ACKNOWLEDGED",
+		TriggeredTime: "2024-05-13T09:00:00Z",
+	},
+}
+
+func TestListAlerts(t *testing.T) {
+	server, store := newAlertServer(t, testAlerts)
+
+	opts := NewOptions()
+	opts.State = "active"
+	opts.Severity = "critical"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.ListAlerts(context.Background()))
+	require.Equal(t, []string{"pageSize=100&severity=CRITICAL&state=ACTIVE", "page=1"}, store.queries)
+	require.Equal(t, `ID: a-1, Severity: CRITICAL, State: ACTIVE, Name: High CPU, TriggeredTime: 2024-05-13T10:00:00Z, Entities: web-01 (e-1), e-2
+ID: a-2, Severity: WARNING, State: ACKNOWLEDGED, Name: Disk almost full, TriggeredTime: 2024-05-13T09:00:00Z
+`, testutil.ReadOutput(t, client.output))
+}
+
+func TestGetAlert(t *testing.T) {
+	server, _ := newAlertServer(t, testAlerts)
+
+	opts := NewOptions()
+	opts.ID = "a-1"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.GetAlert(context.Background()))
+	require.Equal(t, `ID: a-1
+Name: High CPU
+Severity: CRITICAL
+State: ACTIVE
+AlertDefinitionID: def-1
+TriggeredTime: 2024-05-13T10:00:00Z
+Condition:
+  CPU above 90% for 5 minutes
+  system.cpu.utilization > 90 (value: 97.5)
+Entities:
+  ID: e-1, Type: Host, Name: web-01
+  ID: e-2
+`, testutil.ReadOutput(t, client.output))
+}
+
+func TestGetAlertJSON(t *testing.T) {
+	server, _ := newAlertServer(t, testAlerts)
+
+	opts := NewOptions()
+	opts.ID = "a-2"
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.GetAlert(context.Background()))
+
+	var alert Alert
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &alert))
+	require.Equal(t, testAlerts[1], alert)
+}
+
+func TestAlertActions(t *testing.T) {
+	server, store := newAlertServer(t, testAlerts)
+
+	opts := NewOptions()
+	opts.ID = "a-1"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.AcknowledgeAlert(context.Background()))
+	require.NoError(t, client.ResolveAlert(context.Background()))
+	require.Equal(t, []string{"/a-1/acknowledge", "/a-1/resolve"}, store.actions)
+	require.Equal(t, "Alert a-1 acknowledged successfully\nAlert a-1 resolved successfully\n", testutil.ReadOutput(t, client.output))
+
+	opts.ID = "a-resolved"
+	require.ErrorIs(t, client.ResolveAlert(context.Background()), errActionNotPermitted)
+}
+
+func TestAlertActionsDryRun(t *testing.T) {
+	server, store := newAlertServer(t, testAlerts)
+
+	opts := NewOptions()
+	opts.ID = "a-1"
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.AcknowledgeAlert(context.Background()))
+	require.Empty(t, store.actions)
+	output := testutil.ReadOutput(t, client.output)
+	require.True(t, strings.HasPrefix(output, "POST "+server.URL+"/v1/alerts/a-1/acknowledge\n"))
+	require.Contains(t, output, "Dry run: alert a-1 was not acknowledged\n")
+}
+
+func TestAlertActionsJSON(t *testing.T) {
+	server, _ := newAlertServer(t, testAlerts)
+
+	opts := NewOptions()
+	opts.ID = "a-1"
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.ResolveAlert(context.Background()))
+
+	var response actionResponse
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &response))
+	require.Equal(t, actionResponse{Status: "success", ID: "a-1", Action: actionResolve}, response)
+}
+
+func TestFollowAlerts(t *testing.T) {
+	server, store := newAlertServer(t, testAlerts[:1])
+
+	opts := NewOptions()
+	opts.Follow = true
+	opts.Interval = 10 * time.Millisecond
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- client.FollowAlerts(ctx)
+	}()
+
+	// A new alert and a state change of the known alert are printed once each
+	acknowledged := testAlerts[0]
+	acknowledged.State = "ACKNOWLEDGED"
+	time.Sleep(50 * time.Millisecond)
+	store.set([]Alert{acknowledged, testAlerts[1]})
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasPrefix(lines[0], "ID: a-1, Severity: CRITICAL, State: ACTIVE"))
+	require.True(t, strings.HasPrefix(lines[1], "ID: a-1, Severity: CRITICAL, State: ACKNOWLEDGED"))
+	require.True(t, strings.HasPrefix(lines[2], "ID: a-2"))
+}
+
+func TestFollowAlertsReportsDisappeared(t *testing.T) {
+	server, store := newAlertServer(t, testAlerts)
+
+	opts := NewOptions()
+	opts.Follow = true
+	opts.State = "active"
+	opts.Interval = 10 * time.Millisecond
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- client.FollowAlerts(ctx)
+	}()
+
+	// a-1 is resolved and drops out of the listing, a-2 is deleted
+	resolved := testAlerts[0]
+	resolved.State = "RESOLVED"
+	time.Sleep(50 * time.Millisecond)
+	store.set(nil, resolved)
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 4)
+
+	var alerts []Alert
+	for _, line := range lines {
+		var alert Alert
+		require.NoError(t, json.Unmarshal([]byte(line), &alert))
+		alerts = append(alerts, alert)
+	}
+	require.Equal(t, testAlerts, alerts[:2])
+	require.Equal(t, resolved, alerts[2])
+	require.Equal(t, "a-2", alerts[3].ID)
+	require.Equal(t, stateGone, alerts[3].State)
+}
@@ -0,0 +1,84 @@
+package alerts
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+// NewAlertsCommand creates the alerts command
+func NewAlertsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "alerts",
+		Usage: "List, inspect, acknowledge and resolve alerts",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List alerts",
+				Action: runList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "state",
+						Usage: "Only list alerts in this state: active, acknowledged or resolved",
+					},
+					&cli.StringFlag{
+						Name:  "severity",
+						Usage: "Only list alerts of this severity: info, warning or critical",
+					},
+					&cli.BoolFlag{
+						Name:    "follow",
+						Aliases: []string{"f"},
+						Usage:   "Keep watching and print new alerts and state changes until interrupted, alerts that no longer match are printed with their current state",
+					},
+					&cli.DurationFlag{
+						Name:  "interval",
+						Usage: "How often to check for new alerts in follow mode",
+						Value: DefaultFollowInterval,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "Show an alert with its triggering condition and affected entities",
+				ArgsUsage: "ID",
+				Action:    runGet,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "ack",
+				Usage:     "Acknowledge an alert",
+				ArgsUsage: "ID",
+				Action:    runAck,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "resolve",
+				Usage:     "Resolve an alert, if the API permits it for the alert",
+				ArgsUsage: "ID",
+				Action:    runResolve,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+		},
+	}
+}
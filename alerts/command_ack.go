@@ -0,0 +1,35 @@
+package alerts
+
+import (
+	"context"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+func runAck(ctx *cli.Context) error {
+	opts := newAlertOptions(ctx)
+	if err := opts.ValidateForGet(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.AcknowledgeAlert(context.Background())
+}
+
+func runResolve(ctx *cli.Context) error {
+	opts := newAlertOptions(ctx)
+	if err := opts.ValidateForGet(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.ResolveAlert(context.Background())
+}
@@ -0,0 +1,33 @@
+package alerts
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func newAlertOptions(ctx *cli.Context) *Options {
+	opts := NewOptions()
+	opts.ID = ctx.Args().First()
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+	return opts
+}
+
+func runGet(ctx *cli.Context) error {
+	opts := newAlertOptions(ctx)
+	if err := opts.ValidateForGet(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.GetAlert(context.Background())
+}
@@ -0,0 +1,41 @@
+package alerts
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func runList(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.State = ctx.String("state")
+	opts.Severity = ctx.String("severity")
+	opts.Follow = ctx.Bool("follow")
+	opts.Interval = ctx.Duration("interval")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForList(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Follow {
+		return client.ListAlerts(context.Background())
+	}
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return client.FollowAlerts(runCtx)
+}
@@ -0,0 +1,101 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// stateGone is shown for an alert that no longer matches and cannot be fetched
+const stateGone = "GONE"
+
+// printChanged prints the alerts that are new or changed their state since the
+// last poll, remembers them and marks them as current
+func (c *Client) printChanged(alerts []Alert, seen map[string]Alert, current map[string]bool) error {
+	var changed []Alert
+	for _, alert := range alerts {
+		current[alert.ID] = true
+		if known, ok := seen[alert.ID]; ok && known.State == alert.State {
+			continue
+		}
+		seen[alert.ID] = alert
+		changed = append(changed, alert)
+	}
+
+	return c.printAlerts(changed)
+}
+
+// printDisappeared prints the seen alerts that are no longer listed, e.g. alerts
+// resolved while following --state active. They are fetched to show their current
+// state; an alert that cannot be fetched is shown with the state GONE.
+func (c *Client) printDisappeared(ctx context.Context, seen map[string]Alert, current map[string]bool) error {
+	var ids []string
+	for id := range seen {
+		if !current[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	disappeared := make([]Alert, 0, len(ids))
+	for _, id := range ids {
+		alert, err := c.getAlert(ctx, id)
+		if err != nil {
+			slog.Warn("Failed to get alert that is no longer listed", "id", id, "error", err)
+			gone := seen[id]
+			gone.State = stateGone
+			alert = &gone
+		}
+		delete(seen, id)
+		disappeared = append(disappeared, *alert)
+	}
+
+	return c.printAlerts(disappeared)
+}
+
+// FollowAlerts prints the matching alerts and then polls for new alerts and
+// state changes until the context is cancelled. Alerts that stop matching the
+// filters are reported as a transition to their current state.
+func (c *Client) FollowAlerts(ctx context.Context) error {
+	seen := make(map[string]Alert)
+	poll := func() error {
+		current := make(map[string]bool)
+		err := c.listAlerts(ctx, func(alerts []Alert) error {
+			if err := c.printChanged(alerts, seen, current); err != nil {
+				return fmt.Errorf("failed to print result: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Only a complete listing tells which alerts are gone
+		if err := c.printDisappeared(ctx, seen, current); err != nil {
+			return fmt.Errorf("failed to print result: %w", err)
+		}
+		return nil
+	}
+
+	// Fail early if the first request is rejected, e.g. because of a bad token
+	if err := poll(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.opts.Interval):
+		}
+
+		if err := poll(); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			slog.Error("Failed to list alerts", "error", err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultFollowInterval is how often follow mode checks for new alerts
+	DefaultFollowInterval = 10 * time.Second
+)
+
+var (
+	errMissingAlertID  = errors.New("alert ID is required")
+	errInvalidState    = errors.New("invalid state, expected active, acknowledged or resolved")
+	errInvalidSeverity = errors.New("invalid severity, expected info, warning or critical")
+	errInvalidInterval = errors.New("follow interval must be positive")
+
+	states     = []string{"active", "acknowledged", "resolved"}
+	severities = []string{"info", "warning", "critical"}
+)
+
+// Options represents the command line options for the alerts command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	ID                 string
+	State              string
+	Severity           string
+	Follow             bool
+	Interval           time.Duration
+	JSON               bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{
+		Interval: DefaultFollowInterval,
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateForList validates options for list operation
+func (o *Options) ValidateForList() error {
+	o.State = strings.ToLower(strings.TrimSpace(o.State))
+	o.Severity = strings.ToLower(strings.TrimSpace(o.Severity))
+
+	if o.State != "" && !contains(states, o.State) {
+		return fmt.Errorf("%w: %s", errInvalidState, o.State)
+	}
+	if o.Severity != "" && !contains(severities, o.Severity) {
+		return fmt.Errorf("%w: %s", errInvalidSeverity, o.Severity)
+	}
+	if o.Follow && o.Interval <= 0 {
+		return errInvalidInterval
+	}
+	return nil
+}
+
+// ValidateForGet validates options for get, ack and resolve operations
+func (o *Options) ValidateForGet() error {
+	if strings.TrimSpace(o.ID) == "" {
+		return errMissingAlertID
+	}
+	return nil
+}
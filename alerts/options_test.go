@@ -0,0 +1,38 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateForList(t *testing.T) {
+	opts := NewOptions()
+	require.NoError(t, opts.ValidateForList())
+
+	opts.State = " Active "
+	opts.Severity = "CRITICAL"
+	require.NoError(t, opts.ValidateForList())
+	require.Equal(t, "active", opts.State)
+	require.Equal(t, "critical", opts.Severity)
+
+	opts.State = "open"
+	require.ErrorIs(t, opts.ValidateForList(), errInvalidState)
+
+	opts.State = ""
+	opts.Severity = "major"
+	require.ErrorIs(t, opts.ValidateForList(), errInvalidSeverity)
+
+	opts.Severity = ""
+	opts.Follow = true
+	opts.Interval = 0
+	require.Equal(t, errInvalidInterval, opts.ValidateForList())
+}
+
+func TestValidateForGet(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingAlertID, opts.ValidateForGet())
+
+	opts.ID = "a-1"
+	require.NoError(t, opts.ValidateForGet())
+}
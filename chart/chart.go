@@ -0,0 +1,286 @@
+// Package chart renders time series in the terminal as Unicode braille line
+// charts and sparklines.
+package chart
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultWidth is the default width of the plot area in terminal cells
+	DefaultWidth = 60
+	// DefaultHeight is the default height of the plot area in terminal cells
+	DefaultHeight = 12
+
+	// Every braille cell is a grid of 2x4 dots
+	dotsPerColumn = 2
+	dotsPerRow    = 4
+	brailleBlank  = 0x2800
+
+	colorReset = "\033[0m"
+)
+
+// brailleDots maps a dot position within a cell to its bit in the braille pattern
+var brailleDots = [dotsPerRow][dotsPerColumn]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// colors are the ANSI colors used for the series, in order
+var colors = []string{"\033[34m", "\033[31m", "\033[32m", "\033[33m", "\033[35m", "\033[36m"}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Point is a single value of a series
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is a named sequence of points ordered by time
+type Series struct {
+	Name   string
+	Points []Point
+}
+
+// Options configures a line chart
+type Options struct {
+	// Width and Height of the plot area in terminal cells, without axes and legend
+	Width  int
+	Height int
+	// Color draws every series in its own ANSI color
+	Color bool
+}
+
+type bounds struct {
+	minTime, maxTime   time.Time
+	minValue, maxValue float64
+}
+
+func seriesBounds(series []Series) (bounds, bool) {
+	b := bounds{minValue: math.Inf(1), maxValue: math.Inf(-1)}
+	found := false
+	for _, s := range series {
+		for _, p := range s.Points {
+			if !found || p.Time.Before(b.minTime) {
+				b.minTime = p.Time
+			}
+			if !found || p.Time.After(b.maxTime) {
+				b.maxTime = p.Time
+			}
+			b.minValue = math.Min(b.minValue, p.Value)
+			b.maxValue = math.Max(b.maxValue, p.Value)
+			found = true
+		}
+	}
+
+	// Flat series are drawn in the middle of the plot
+	if b.minValue == b.maxValue {
+		b.minValue--
+		b.maxValue++
+	}
+	return b, found
+}
+
+// canvas is a grid of braille cells, each cell remembering the series that drew last
+type canvas struct {
+	width, height int
+	cells         [][]rune
+	owners        [][]int
+}
+
+func newCanvas(width, height int) *canvas {
+	c := &canvas{width: width, height: height, cells: make([][]rune, height), owners: make([][]int, height)}
+	for row := range c.cells {
+		c.cells[row] = make([]rune, width)
+		c.owners[row] = make([]int, width)
+		for col := range c.cells[row] {
+			c.cells[row][col] = brailleBlank
+			c.owners[row][col] = -1
+		}
+	}
+	return c
+}
+
+func (c *canvas) set(x, y int, owner int) {
+	if x < 0 || y < 0 || x >= c.width*dotsPerColumn || y >= c.height*dotsPerRow {
+		return
+	}
+	row, col := y/dotsPerRow, x/dotsPerColumn
+	c.cells[row][col] |= brailleDots[y%dotsPerRow][x%dotsPerColumn]
+	c.owners[row][col] = owner
+}
+
+// line draws a line between two dots with Bresenham's algorithm
+func (c *canvas) line(x0, y0, x1, y1 int, owner int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	e := dx + dy
+	for {
+		c.set(x0, y0, owner)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if 2*e >= dy {
+			e += dy
+			x0 += sx
+		}
+		if 2*e <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// FormatValue formats axis values without needless digits
+func FormatValue(value float64) string {
+	switch {
+	case value == math.Trunc(value) && math.Abs(value) < 1e15:
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	case math.Abs(value) >= 100:
+		return strconv.FormatFloat(value, 'f', 1, 64)
+	default:
+		return strconv.FormatFloat(value, 'g', 3, 64)
+	}
+}
+
+func timeLayout(from, to time.Time) string {
+	if to.Sub(from) < 24*time.Hour {
+		return "15:04"
+	}
+	return "01-02 15:04"
+}
+
+// Line writes a braille line chart of the series with a value axis on the left,
+// a time axis below and a legend naming every series
+func Line(w io.Writer, series []Series, opts Options) error {
+	if opts.Width <= 0 {
+		opts.Width = DefaultWidth
+	}
+	if opts.Height <= 0 {
+		opts.Height = DefaultHeight
+	}
+
+	b, ok := seriesBounds(series)
+	if !ok {
+		_, err := fmt.Fprintln(w, "No data points")
+		return err
+	}
+
+	c := newCanvas(opts.Width, opts.Height)
+	maxX := float64(opts.Width*dotsPerColumn - 1)
+	maxY := float64(opts.Height*dotsPerRow - 1)
+	span := b.maxTime.Sub(b.minTime)
+
+	for i, s := range series {
+		prevX, prevY := -1, -1
+		for _, p := range s.Points {
+			x := 0
+			if span > 0 {
+				x = int(math.Round(float64(p.Time.Sub(b.minTime)) / float64(span) * maxX))
+			}
+			y := int(math.Round((b.maxValue - p.Value) / (b.maxValue - b.minValue) * maxY))
+			if prevX < 0 {
+				c.set(x, y, i)
+			} else {
+				c.line(prevX, prevY, x, y, i)
+			}
+			prevX, prevY = x, y
+		}
+	}
+
+	top, middle, bottom := FormatValue(b.maxValue), FormatValue((b.maxValue+b.minValue)/2), FormatValue(b.minValue)
+	axisWidth := max(len(top), len(middle), len(bottom))
+
+	var out strings.Builder
+	for row := 0; row < opts.Height; row++ {
+		label, tick := "", "│"
+		switch row {
+		case 0:
+			label, tick = top, "┤"
+		case opts.Height / 2:
+			label, tick = middle, "┤"
+		case opts.Height - 1:
+			label, tick = bottom, "┤"
+		}
+		out.WriteString(fmt.Sprintf("%*s %s", axisWidth, label, tick))
+		writeCells(&out, c.cells[row], c.owners[row], opts.Color)
+		out.WriteString("\n")
+	}
+	out.WriteString(fmt.Sprintf("%*s └%s\n", axisWidth, "", strings.Repeat("─", opts.Width)))
+
+	layout := timeLayout(b.minTime, b.maxTime)
+	from, to := b.minTime.Local().Format(layout), b.maxTime.Local().Format(layout)
+	gap := max(opts.Width-len(from)-len(to), 1)
+	out.WriteString(fmt.Sprintf("%*s  %s%s%s\n", axisWidth, "", from, strings.Repeat(" ", gap), to))
+
+	for i, s := range series {
+		marker := "━━"
+		if opts.Color {
+			marker = colors[i%len(colors)] + marker + colorReset
+		}
+		out.WriteString(fmt.Sprintf("%*s  %s %s\n", axisWidth, "", marker, s.Name))
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func writeCells(out *strings.Builder, cells []rune, owners []int, color bool) {
+	current := -1
+	for col, cell := range cells {
+		if color && owners[col] != current && owners[col] >= 0 {
+			out.WriteString(colors[owners[col]%len(colors)])
+			current = owners[col]
+		}
+		out.WriteRune(cell)
+	}
+	if color && current >= 0 {
+		out.WriteString(colorReset)
+	}
+}
+
+// Sparkline renders values as a single line of block characters scaled between
+// their lowest and highest value
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+
+	var out strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2
+		if high > low {
+			level = int(math.Round((v - low) / (high - low) * float64(len(sparkBlocks)-1)))
+		}
+		out.WriteRune(sparkBlocks[level])
+	}
+	return out.String()
+}
@@ -0,0 +1,94 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC)
+
+func points(values ...float64) []Point {
+	result := make([]Point, len(values))
+	for i, v := range values {
+		result[i] = Point{Time: start.Add(time.Duration(i) * time.Minute), Value: v}
+	}
+	return result
+}
+
+func TestSparkline(t *testing.T) {
+	require.Equal(t, "", Sparkline(nil))
+	require.Equal(t, "▁▂▃▄▅▆▇█", Sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}))
+	require.Equal(t, "█▁█", Sparkline([]float64{10, -10, 10}))
+	require.Equal(t, "▅▅▅", Sparkline([]float64{3, 3, 3}))
+}
+
+func TestFormatValue(t *testing.T) {
+	require.Equal(t, "42", FormatValue(42))
+	require.Equal(t, "1234.6", FormatValue(1234.56))
+	require.Equal(t, "0.123", FormatValue(0.12345))
+	require.Equal(t, "-2.5", FormatValue(-2.5))
+}
+
+func TestCanvasLine(t *testing.T) {
+	c := newCanvas(2, 1)
+	c.line(0, 0, 3, 3, 0)
+
+	// The diagonal sets one dot per column, crossing both cells
+	require.Equal(t, rune(brailleBlank|0x01|0x10), c.cells[0][0])
+	require.Equal(t, rune(brailleBlank|0x04|0x80), c.cells[0][1])
+	require.Equal(t, []int{0, 0}, c.owners[0])
+
+	// Dots outside the canvas are ignored
+	c.set(10, 10, 1)
+	require.Equal(t, []int{0, 0}, c.owners[0])
+}
+
+func TestLine(t *testing.T) {
+	time.Local = time.UTC
+
+	var out strings.Builder
+	require.NoError(t, Line(&out, []Series{
+		{Name: "rising", Points: points(0, 1, 2, 3)},
+		{Name: "flat", Points: points(2, 2, 2, 2)},
+	}, Options{Width: 4, Height: 3}))
+
+	lines := strings.Split(out.String(), "\n")
+	require.Len(t, lines, 8)
+	require.True(t, strings.HasPrefix(lines[0], "  3 ┤"), lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "1.5 ┤"), lines[1])
+	require.True(t, strings.HasPrefix(lines[2], "  0 ┤"), lines[2])
+	require.Equal(t, []string{
+		"    └────",
+		"     10:00 10:03",
+		"     ━━ rising",
+		"     ━━ flat",
+		"",
+	}, lines[3:])
+
+	// Every column of the plot has at least one dot
+	for col := 0; col < 4; col++ {
+		dots := []rune(lines[0])[5+col] | []rune(lines[1])[5+col] | []rune(lines[2])[5+col]
+		require.NotEqual(t, rune(brailleBlank), dots, "column %d", col)
+	}
+
+	// The rising series starts in the bottom left and ends in the top right
+	require.NotEqual(t, rune(brailleBlank), []rune(lines[2])[5])
+	require.NotEqual(t, rune(brailleBlank), []rune(lines[0])[8])
+}
+
+func TestLineColor(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, Line(&out, []Series{{Name: "a", Points: points(1, 2)}, {Name: "b", Points: points(2, 1)}}, Options{Color: true}))
+
+	require.Contains(t, out.String(), colors[0]+"━━"+colorReset+" a")
+	require.Contains(t, out.String(), colors[1]+"━━"+colorReset+" b")
+}
+
+func TestLineWithoutPoints(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, Line(&out, []Series{{Name: "empty"}}, Options{}))
+	require.Equal(t, "No data points\n", out.String())
+}
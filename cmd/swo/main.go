@@ -4,9 +4,20 @@
 import (
 	"log"
 	"os"
+	"strings"
 
+	"github.com/solarwinds/swo-cli/alertdefinitions"
+	"github.com/solarwinds/swo-cli/alerts"
 	"github.com/solarwinds/swo-cli/config"
+	"github.com/solarwinds/swo-cli/db"
 	"github.com/solarwinds/swo-cli/entities"
+	"github.com/solarwinds/swo-cli/events"
+	"github.com/solarwinds/swo-cli/metrics"
+	"github.com/solarwinds/swo-cli/notifications"
+	"github.com/solarwinds/swo-cli/services"
+	"github.com/solarwinds/swo-cli/traces"
+	"github.com/solarwinds/swo-cli/uptime"
+	"github.com/solarwinds/swo-cli/websites"
 
 	"github.com/solarwinds/swo-cli/logs"
 	cli "github.com/urfave/cli/v2"
@@ -15,46 +26,110 @@
 // Automatically updated by goreleaser in CI
 var version = "v1.3.7"
 
+// localCommands work on local files only and therefore run without a token. The
+// function tells whether a call of the command is local. Commands registered at
+// more than one path are listed at each of them.
+var localCommands = map[string]func(cCtx *cli.Context) bool{
+	"alert-definitions validate":    always,
+	"entities cache clear":          always,
+	"entities maintenance schedule": always,
+	"maintenance schedule":          always,
+	// Comparing two snapshot files needs no API access, only the comparison with
+	// the live inventory does
+	"entities diff": func(cCtx *cli.Context) bool { return cCtx.NArg() == 2 },
+}
+
+func always(*cli.Context) bool {
+	return true
+}
+
 func main() {
 	app := &cli.App{
 		Name:    "swo",
 		Usage:   "SolarWinds Observability Command-Line Interface",
 		Version: version,
+		// Entity names are completed from the local entity cache
+		EnableBashCompletion: true,
 		Flags: []cli.Flag{
 			&cli.StringFlag{Name: config.APIURLContextKey, Usage: "URL of the SWO API", Value: config.DefaultAPIURL},
 			&cli.StringFlag{Name: config.TokenContextKey, Usage: "API token"},
 			&cli.StringFlag{Name: "config", Aliases: []string{"c"}, Usage: "path to config", Value: config.DefaultConfigFile},
 			&cli.BoolFlag{Name: "verbose", Usage: "enable verbose output (shows API URLs and debug info)"},
+			&cli.BoolFlag{Name: config.DryRunContextKey, Usage: "print mutating API requests and a diff of the changes instead of sending them"},
 		},
-		Commands: []*cli.Command{
+		Commands: withConfig("", []*cli.Command{
 			logs.NewLogsCommand(),
 			entities.NewEntitiesCommand(),
-		},
-		Before: func(cCtx *cli.Context) error {
-			// Only pass CLI values if they were explicitly set by the user
-			var apiURL, apiToken string
-			if cCtx.IsSet(config.APIURLContextKey) {
-				apiURL = cCtx.String(config.APIURLContextKey)
-			}
-			if cCtx.IsSet(config.TokenContextKey) {
-				apiToken = cCtx.String(config.TokenContextKey)
-			}
-
-			cfg, err := config.Init(cCtx.String("config"), apiURL, apiToken)
-			if err != nil {
-				return err
-			}
-			if err = cCtx.Set(config.APIURLContextKey, cfg.APIURL); err != nil {
-				return err
-			}
-			if err = cCtx.Set(config.TokenContextKey, cfg.Token); err != nil {
-				return err
-			}
-			return nil
-		},
+			entities.NewMaintenanceCommand(),
+			metrics.NewMetricsCommand(),
+			events.NewEventsCommand(),
+			alerts.NewAlertsCommand(),
+			alertdefinitions.NewAlertDefinitionsCommand(),
+			notifications.NewNotificationsCommand(),
+			uptime.NewUptimeCommand(),
+			websites.NewWebsitesCommand(),
+			traces.NewTracesCommand(),
+			services.NewServicesCommand(),
+			db.NewDBCommand(),
+		}),
 	}
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// withConfig loads the configuration before the action of every command, except
+// for the localCommands. The check runs on the resolved command, so flags between
+// a command and its subcommand do not matter.
+func withConfig(parent string, commands []*cli.Command) []*cli.Command {
+	for _, command := range commands {
+		path := strings.TrimSpace(parent + " " + command.Name)
+		withConfig(path, command.Subcommands)
+
+		action := command.Action
+		if action == nil {
+			continue
+		}
+		local := localCommands[path]
+		command.Action = func(cCtx *cli.Context) error {
+			if local == nil || !local(cCtx) {
+				if err := initConfig(cCtx); err != nil {
+					return err
+				}
+			}
+			return action(cCtx)
+		}
+	}
+	return commands
+}
+
+func initConfig(cCtx *cli.Context) error {
+	// Only pass CLI values if they were explicitly set by the user
+	var apiURL, apiToken string
+	if cCtx.IsSet(config.APIURLContextKey) {
+		apiURL = cCtx.String(config.APIURLContextKey)
+	}
+	if cCtx.IsSet(config.TokenContextKey) {
+		apiToken = cCtx.String(config.TokenContextKey)
+	}
+
+	// Reads from the local entity cache need the API URL the cache was written
+	// for, but no token
+	load := config.Init
+	if cCtx.Bool("offline") {
+		load = config.Load
+	}
+
+	cfg, err := load(cCtx.String("config"), apiURL, apiToken)
+	if err != nil {
+		return err
+	}
+	if err = cCtx.Set(config.APIURLContextKey, cfg.APIURL); err != nil {
+		return err
+	}
+	if err = cCtx.Set(config.TokenContextKey, cfg.Token); err != nil {
+		return err
+	}
+	return nil
+}
@@ -5,10 +5,10 @@
 	"errors"
 	"fmt"
 	"os"
-	"os/user"
 	"path/filepath"
 	"strings"
 
+	"github.com/solarwinds/swo-cli/shared"
 	yaml "gopkg.in/yaml.v3"
 )
 
@@ -23,6 +23,8 @@
 	TokenContextKey = "api-token"
 	// VerboseContextKey is the context key for verbose output
 	VerboseContextKey = "verbose"
+	// DryRunContextKey is the context key for previewing mutating requests without sending them
+	DryRunContextKey = "dry-run"
 )
 
 var (
@@ -39,6 +41,21 @@ type Config struct {
 // environment variables, and command line flags.
 // Precedence: CLI flags, environment, config file
 func Init(configPath string, apiURL string, apiToken string) (*Config, error) {
+	config, err := Load(configPath, apiURL, apiToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Token == "" {
+		return nil, errMissingToken
+	}
+
+	return config, nil
+}
+
+// Load resolves the configuration like Init, but does not require a token, for
+// commands that only read local data
+func Load(configPath string, apiURL string, apiToken string) (*Config, error) {
 	// initialize values from CMD line
 	config := &Config{
 		APIURL: strings.TrimSpace(apiURL),
@@ -62,13 +79,11 @@ func Init(configPath string, apiURL string, apiToken string) (*Config, error) {
 		localConfig := filepath.Join(cwd, ".swo-cli.yaml")
 		if _, err := os.Stat(localConfig); err == nil {
 			configPath = localConfig
-		} else if strings.HasPrefix(configPath, "~/") {
-			usr, err := user.Current()
+		} else {
+			configPath, err = shared.ExpandHome(configPath)
 			if err != nil {
-				return nil, fmt.Errorf("error while resolving current user to read configuration file: %w", err)
+				return nil, fmt.Errorf("error while resolving configuration file: %w", err)
 			}
-
-			configPath = filepath.Join(usr.HomeDir, configPath[2:])
 		}
 		configPath = filepath.Clean(configPath)
 
@@ -94,9 +109,5 @@ func Init(configPath string, apiURL string, apiToken string) (*Config, error) {
 		config.APIURL = DefaultAPIURL
 	}
 
-	if config.Token == "" {
-		return nil, errMissingToken
-	}
-
 	return config, nil
 }
@@ -3,6 +3,7 @@
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -462,3 +463,18 @@ func TestEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadWithoutToken(t *testing.T) {
+	_ = os.Setenv("SWO_API_TOKEN", "")
+	_ = os.Setenv("SWO_API_URL", "")
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("api-url: https://api.eu-01.cloud.solarwinds.com\n"), 0o600))
+
+	_, err := Init(configFile, "", "")
+	require.ErrorIs(t, err, errMissingToken)
+
+	cfg, err := Load(configFile, "", "")
+	require.NoError(t, err)
+	require.Equal(t, &Config{APIURL: "https://api.eu-01.cloud.solarwinds.com"}, cfg)
+}
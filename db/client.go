@@ -0,0 +1,312 @@
+// Package db provides a client for database instances and their heaviest
+// queries from SWO database monitoring.
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultPageSize for retrieving list of database instances
+	DefaultPageSize = 100
+
+	// maxQueryWidth is how many characters of a query the table shows
+	maxQueryWidth = 80
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+)
+
+// Client is a database client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	output     *os.File
+}
+
+// Database is a database instance entity
+type Database struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Query is a normalized query with its statistics over the requested time
+// range, times are in milliseconds
+type Query struct {
+	ID        string  `json:"queryId"`
+	Query     string  `json:"query"`
+	Calls     int64   `json:"calls"`
+	TotalTime float64 `json:"totalTimeMs"`
+	AvgTime   float64 `json:"avgTimeMs"`
+	P95Time   float64 `json:"p95TimeMs"`
+	Errors    int64   `json:"errors,omitempty"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type listDatabasesResponse struct {
+	Databases []Database `json:"entities"`
+	pageInfo  `json:"pageInfo"`
+}
+
+type topQueriesResponse struct {
+	Queries []Query `json:"queries"`
+}
+
+// NewClient creates a new database client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		output:     os.Stdout,
+	}, nil
+}
+
+func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("type", c.opts.Type)
+	if c.opts.Name != "" {
+		params.Add("name", c.opts.Name)
+	}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/entities")
+}
+
+func (c *Client) prepareTopQueriesRequest(ctx context.Context) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("sortBy", sortFields[c.opts.By])
+	params.Add("pageSize", strconv.Itoa(c.opts.Limit))
+	if c.opts.MinTime != "" {
+		params.Add("startTime", c.opts.MinTime)
+	}
+	if c.opts.MaxTime != "" {
+		params.Add("endTime", c.opts.MaxTime)
+	}
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, "", "v1/databases", c.opts.ID, "queries")
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+func (c *Client) getDatabases(ctx context.Context, nextPage string) (*listDatabasesResponse, error) {
+	request, err := c.prepareListRequest(ctx, nextPage)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response listDatabasesResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (c *Client) getTopQueries(ctx context.Context) ([]Query, error) {
+	request, err := c.prepareTopQueriesRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response topQueriesResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return response.Queries, nil
+}
+
+func (c *Client) printDatabases(databases []Database) error {
+	for _, database := range databases {
+		if c.opts.JSON {
+			jsonData, err := json.Marshal(database)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(c.output, string(jsonData))
+			continue
+		}
+
+		_, _ = fmt.Fprintf(c.output, "ID: %s, Type: %s", database.ID, database.Type)
+		if database.Name != "" {
+			_, _ = fmt.Fprintf(c.output, ", Name: %s", database.Name)
+		}
+		if database.DisplayName != "" {
+			_, _ = fmt.Fprintf(c.output, ", DisplayName: %s", database.DisplayName)
+		}
+		_, _ = fmt.Fprintln(c.output)
+	}
+	return nil
+}
+
+// sortQueries orders the queries by the --by field, heaviest first. The API
+// sorts already, this keeps the order stable when it ignores the parameter.
+func sortQueries(queries []Query, by string) {
+	key := map[string]func(q Query) float64{
+		"total-time": func(q Query) float64 { return q.TotalTime },
+		"avg-time":   func(q Query) float64 { return q.AvgTime },
+		"p95-time":   func(q Query) float64 { return q.P95Time },
+		"calls":      func(q Query) float64 { return float64(q.Calls) },
+	}[by]
+
+	sort.SliceStable(queries, func(i, j int) bool {
+		return key(queries[i]) > key(queries[j])
+	})
+}
+
+// formatMillis formats a duration in milliseconds with a unit that fits its size
+func formatMillis(ms float64) string {
+	switch {
+	case ms >= 60*1000:
+		return time.Duration(ms * float64(time.Millisecond)).Round(time.Second).String()
+	case ms >= 1000:
+		return strconv.FormatFloat(ms/1000, 'f', 2, 64) + "s"
+	default:
+		return strconv.FormatFloat(ms, 'f', 1, 64) + "ms"
+	}
+}
+
+// shortQuery collapses the whitespace of a query to a single line and truncates it
+func shortQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	runes := []rune(query)
+	if len(runes) > maxQueryWidth {
+		return string(runes[:maxQueryWidth-1]) + "…"
+	}
+	return query
+}
+
+func (c *Client) printQueries(queries []Query) error {
+	if c.opts.JSON {
+		for _, query := range queries {
+			jsonData, err := json.Marshal(query)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(c.output, string(jsonData))
+		}
+		return nil
+	}
+
+	if len(queries) == 0 {
+		_, _ = fmt.Fprintln(c.output, "No queries found")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(c.output, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TOTAL TIME\tCALLS\tAVG\tP95\tERRORS\tQUERY")
+	for _, q := range queries {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%d\t%s\n", formatMillis(q.TotalTime), q.Calls,
+			formatMillis(q.AvgTime), formatMillis(q.P95Time), q.Errors, shortQuery(q.Query))
+	}
+	return tw.Flush()
+}
+
+// ListDatabases retrieves and displays the database instances
+func (c *Client) ListDatabases(ctx context.Context) error {
+	var nextPage string
+
+	for {
+		response, err := c.getDatabases(ctx, nextPage)
+		if err != nil {
+			return err
+		}
+
+		if err := c.printDatabases(response.Databases); err != nil {
+			return fmt.Errorf("failed to print result: %w", err)
+		}
+
+		if response.NextPage == "" {
+			return nil
+		}
+		nextPage = response.NextPage
+	}
+}
+
+// TopQueries retrieves and displays the heaviest normalized queries of a database
+func (c *Client) TopQueries(ctx context.Context) error {
+	queries, err := c.getTopQueries(ctx)
+	if err != nil {
+		return err
+	}
+
+	sortQueries(queries, c.opts.By)
+	if len(queries) > c.opts.Limit {
+		queries = queries[:c.opts.Limit]
+	}
+
+	if err := c.printQueries(queries); err != nil {
+		return fmt.Errorf("failed to print result: %w", err)
+	}
+	return nil
+}
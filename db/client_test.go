@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testDatabases = []Database{
+		{ID: "d-1", Type: DefaultType, Name: "orders-primary", DisplayName: "Orders (primary)"},
+		{ID: "d-2", Type: DefaultType, Name: "orders-replica"},
+	}
+
+	// testQueries are returned in no particular order
+	testQueries = []Query{
+		{ID: "q-1", Query: "SELECT * FROM orders WHERE id = ?", Calls: 120000, TotalTime: 36000, AvgTime: 0.3, P95Time: 1.2},
+		{ID: "q-2", Query: "UPDATE   inventory\n  SET count = count - ?\n  WHERE sku = ?", Calls: 800, TotalTime: 95000, AvgTime: 118.75, P95Time: 410, Errors: 3},
+		{ID: "q-3", Query: "SELECT " + strings.Repeat("column, ", 20) + "id FROM reports", Calls: 12, TotalTime: 14400, AvgTime: 1200, P95Time: 2500},
+	}
+)
+
+func newDatabaseServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		require.Equal(t, "GET", r.Method)
+		query := r.URL.Query()
+
+		switch r.URL.Path {
+		case "/v1/entities":
+			require.Equal(t, DefaultType, query.Get("type"))
+			page, _ := strconv.Atoi(query.Get("page"))
+			response := listDatabasesResponse{Databases: testDatabases[page : page+1]}
+			if page+1 < len(testDatabases) {
+				response.NextPage = "/v1/entities?type=" + DefaultType + "&page=" + strconv.Itoa(page+1)
+			}
+			testutil.WriteJSON(t, w, response)
+		case "/v1/databases/d-1/queries":
+			require.NotEmpty(t, query.Get("sortBy"))
+			require.Equal(t, "2024-05-13T11:00:00Z", query.Get("startTime"))
+			testutil.WriteJSON(t, w, topQueriesResponse{Queries: testQueries})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestListDatabases(t *testing.T) {
+	server := newDatabaseServer(t)
+
+	opts := NewOptions()
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.ListDatabases(context.Background()))
+
+	require.Equal(t, "ID: d-1, Type: DatabaseInstance, Name: orders-primary, DisplayName: Orders (primary)\n"+
+		"ID: d-2, Type: DatabaseInstance, Name: orders-replica\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestListDatabasesJSON(t *testing.T) {
+	server := newDatabaseServer(t)
+
+	opts := NewOptions()
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.ListDatabases(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 2)
+
+	var database Database
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &database))
+	require.Equal(t, testDatabases[1], database)
+}
+
+func newTopQueriesOptions(by string) *Options {
+	opts := NewOptions()
+	opts.ID = "d-1"
+	opts.By = by
+	opts.MinTime = "2024-05-13T11:00:00Z"
+	return opts
+}
+
+func TestTopQueries(t *testing.T) {
+	server := newDatabaseServer(t)
+
+	opts := newTopQueriesOptions("total-time")
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.TopQueries(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 4)
+	require.Equal(t, []string{"TOTAL", "TIME", "CALLS", "AVG", "P95", "ERRORS", "QUERY"}, strings.Fields(lines[0]))
+
+	// Heaviest first, with the query on a single line
+	require.True(t, strings.HasPrefix(lines[1], "1m35s "))
+	require.True(t, strings.HasSuffix(lines[1], "  3       UPDATE inventory SET count = count - ? WHERE sku = ?"))
+	require.Equal(t, []string{"36.00s", "120000", "0.3ms", "1.2ms", "0"}, strings.Fields(lines[2])[:5])
+	require.True(t, strings.HasPrefix(lines[3], "14.40s"))
+	require.True(t, strings.HasSuffix(lines[3], "…"))
+}
+
+func TestTopQueriesByCallsWithLimit(t *testing.T) {
+	server := newDatabaseServer(t)
+
+	opts := newTopQueriesOptions("calls")
+	opts.Limit = 2
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.TopQueries(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 2)
+
+	var query Query
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &query))
+	require.Equal(t, testQueries[0], query)
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &query))
+	require.Equal(t, "q-2", query.ID)
+}
+
+func TestTopQueriesUnknownDatabase(t *testing.T) {
+	server := newDatabaseServer(t)
+
+	opts := newTopQueriesOptions("total-time")
+	opts.ID = "d-9"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.ErrorIs(t, client.TopQueries(context.Background()), ErrInvalidAPIResponse)
+}
+
+func TestShortQuery(t *testing.T) {
+	require.Equal(t, "SELECT 1", shortQuery("  SELECT\n\t1 "))
+
+	short := shortQuery(strings.Repeat("é", 100))
+	require.Equal(t, maxQueryWidth, len([]rune(short)))
+	require.True(t, strings.HasSuffix(short, "…"))
+}
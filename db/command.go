@@ -0,0 +1,73 @@
+package db
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+// NewDBCommand creates the db command
+func NewDBCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "db",
+		Usage: "Inspect database instances and their heaviest queries",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List database instances",
+				Action: runList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Entity type of the database instances",
+						Value: DefaultType,
+					},
+					&cli.StringFlag{
+						Name:    "name",
+						Aliases: []string{"n"},
+						Usage:   "Only list databases with this name",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:   "top-queries",
+				Usage:  "Show the heaviest normalized queries of a database with call counts and latency",
+				Action: runTopQueries,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "ID of the database instance",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "by",
+						Usage: "Order queries by total-time, avg-time, p95-time or calls",
+						Value: DefaultSort,
+					},
+					&cli.IntFlag{
+						Name:  "limit",
+						Usage: "Number of queries to show",
+						Value: DefaultLimit,
+					},
+					&cli.StringFlag{
+						Name:  "min-time",
+						Usage: "Start of the time range",
+						Value: "1 hour ago",
+					},
+					&cli.StringFlag{
+						Name:  "max-time",
+						Usage: "End of the time range",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func runTopQueries(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.ID = ctx.String("id")
+	opts.By = ctx.String("by")
+	opts.Limit = ctx.Int("limit")
+	opts.MinTime = ctx.String("min-time")
+	opts.MaxTime = ctx.String("max-time")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForTopQueries(); err != nil {
+		return err
+	}
+	if err := opts.Init(time.Now()); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.TopQueries(context.Background())
+}
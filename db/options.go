@@ -0,0 +1,92 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultType is the entity type of database instances monitored by SWO
+	DefaultType = "DatabaseInstance"
+	// DefaultLimit is how many queries top-queries shows
+	DefaultLimit = 10
+	// DefaultSort orders top queries by the time they spent in total
+	DefaultSort = "total-time"
+)
+
+var (
+	errMissingID    = errors.New("database ID is required, use --id")
+	errMissingType  = errors.New("entity type is required")
+	errInvalidSort  = errors.New("invalid --by value, expected total-time, avg-time, p95-time or calls")
+	errInvalidLimit = errors.New("limit must be at least 1")
+
+	// sortFields maps the accepted --by values to the API values
+	sortFields = map[string]string{
+		"total-time": "totalTime",
+		"avg-time":   "avgTime",
+		"p95-time":   "p95Time",
+		"calls":      "calls",
+	}
+)
+
+// Options represents the command line options for the db command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	ID                 string
+	Type               string
+	Name               string
+	By                 string
+	Limit              int
+	MinTime            string
+	MaxTime            string
+	JSON               bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{
+		Type:  DefaultType,
+		By:    DefaultSort,
+		Limit: DefaultLimit,
+	}
+}
+
+// Init parses the time flags into RFC3339 timestamps relative to now
+func (o *Options) Init(now time.Time) error {
+	minTime, maxTime, err := shared.ParseTimeRange(o.MinTime, o.MaxTime, now)
+	if err != nil {
+		return err
+	}
+
+	o.MinTime, o.MaxTime = minTime, maxTime
+	return nil
+}
+
+// ValidateForList validates options for list operation
+func (o *Options) ValidateForList() error {
+	o.Type = strings.TrimSpace(o.Type)
+	if o.Type == "" {
+		return errMissingType
+	}
+	return nil
+}
+
+// ValidateForTopQueries validates options for top-queries operation
+func (o *Options) ValidateForTopQueries() error {
+	o.ID = strings.TrimSpace(o.ID)
+	if o.ID == "" {
+		return errMissingID
+	}
+	o.By = strings.ToLower(strings.TrimSpace(o.By))
+	if _, ok := sortFields[o.By]; !ok {
+		return fmt.Errorf("%w: %s", errInvalidSort, o.By)
+	}
+	if o.Limit < 1 {
+		return errInvalidLimit
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	now := time.Date(2024, 5, 13, 12, 0, 0, 0, time.UTC)
+
+	opts := NewOptions()
+	opts.MinTime = "1 hour ago"
+	opts.MaxTime = "now"
+	require.NoError(t, opts.Init(now))
+	require.Equal(t, "2024-05-13T11:00:00Z", opts.MinTime)
+	require.Equal(t, "2024-05-13T12:00:00Z", opts.MaxTime)
+
+	opts = NewOptions()
+	opts.MinTime = "what?"
+	require.ErrorIs(t, opts.Init(now), shared.ErrMinTimeFlag)
+
+	opts = NewOptions()
+	opts.MinTime = "1 hour ago"
+	opts.MaxTime = "2 hours ago"
+	require.Equal(t, shared.ErrInvalidTimeRange, opts.Init(now))
+}
+
+func TestValidateForList(t *testing.T) {
+	opts := NewOptions()
+	require.NoError(t, opts.ValidateForList())
+
+	opts.Type = " "
+	require.Equal(t, errMissingType, opts.ValidateForList())
+}
+
+func TestValidateForTopQueries(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingID, opts.ValidateForTopQueries())
+
+	opts.ID = "d-1"
+	require.NoError(t, opts.ValidateForTopQueries())
+
+	opts.By = " Calls "
+	require.NoError(t, opts.ValidateForTopQueries())
+	require.Equal(t, "calls", opts.By)
+
+	opts.By = "rows"
+	require.ErrorIs(t, opts.ValidateForTopQueries(), errInvalidSort)
+
+	opts.By = DefaultSort
+	opts.Limit = 0
+	require.Equal(t, errInvalidLimit, opts.ValidateForTopQueries())
+}
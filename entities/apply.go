@@ -0,0 +1,293 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var (
+	errInvalidApplyEntry = errors.New("each entry requires either an id or a selector with a type")
+	errApplyFailed       = errors.New("failed to apply changes to some entities")
+)
+
+// applyFile is the declarative description of desired entity tags
+//
+//	entities:
+//	  - id: e-1234567890
+//	    tags:
+//	      env: production
+//	  - selector:
+//	      type: Host
+//	      name: web-01
+//	    tags:
+//	      team: backend
+//	    removeTags: [deprecated]
+type applyFile struct {
+	Entities []applyEntry `yaml:"entities"`
+}
+
+type applyEntry struct {
+	ID          string            `yaml:"id"`
+	Selector    *applySelector    `yaml:"selector"`
+	Tags        map[string]string `yaml:"tags"`
+	RemoveTags  []string          `yaml:"removeTags"`
+	ReplaceTags bool              `yaml:"replaceTags"`
+}
+
+type applySelector struct {
+	Type string `yaml:"type"`
+	Name string `yaml:"name"`
+}
+
+// entityPlan holds the tag changes planned for a single entity
+type entityPlan struct {
+	ID      string      `json:"id"`
+	Type    string      `json:"type"`
+	Name    string      `json:"name,omitempty"`
+	Changes []TagChange `json:"changes"`
+	Status  string      `json:"status,omitempty"`
+	Error   string      `json:"error,omitempty"`
+
+	current *Entity
+	desired *Entity
+}
+
+// skippedEntity is a snapshot entity that could not be matched to a live entity
+type skippedEntity struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+type applyResponse struct {
+	Plan    []*entityPlan   `json:"plan"`
+	Skipped []skippedEntity `json:"skipped,omitempty"`
+}
+
+func loadApplyFile(path string) (*applyFile, error) {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	file := &applyFile{}
+	if err := yaml.Unmarshal(content, file); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling %s: %w", path, err)
+	}
+
+	for i, entry := range file.Entities {
+		hasSelector := entry.Selector != nil && strings.TrimSpace(entry.Selector.Type) != ""
+		if (entry.ID == "") == !hasSelector {
+			return nil, fmt.Errorf("%w: entry %d in %s", errInvalidApplyEntry, i+1, path)
+		}
+	}
+
+	return file, nil
+}
+
+// tagOptions converts an entry to options understood by computeTags
+func (e *applyEntry) tagOptions() *Options {
+	return &Options{
+		Tags:        e.Tags,
+		RemoveTags:  e.RemoveTags,
+		ReplaceTags: e.ReplaceTags,
+	}
+}
+
+// resolve returns the entities matched by the entry
+func (c *Client) resolve(ctx context.Context, entry *applyEntry) ([]Entity, error) {
+	if entry.ID != "" {
+		entity, err := c.getEntity(ctx, entry.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get entity %s: %w", entry.ID, err)
+		}
+		return []Entity{*entity}, nil
+	}
+
+	entities, err := c.fetchEntities(ctx, entry.Selector.Type, entry.Selector.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entities of type %s: %w", entry.Selector.Type, err)
+	}
+	return entities, nil
+}
+
+// planner accumulates tag changes per entity. Changes are applied in the order
+// they are added, so an entity matched several times gets all of them.
+type planner struct {
+	plans []*entityPlan
+	byID  map[string]*entityPlan
+}
+
+func newPlanner() *planner {
+	return &planner{byID: make(map[string]*entityPlan)}
+}
+
+func (p *planner) add(entity Entity, tagOpts *Options) {
+	plan, ok := p.byID[entity.ID]
+	if !ok {
+		current := entity
+		desired := entity
+		plan = &entityPlan{ID: entity.ID, Type: entity.Type, Name: entity.Name, current: &current, desired: &desired}
+		p.byID[entity.ID] = plan
+		p.plans = append(p.plans, plan)
+	}
+	plan.desired.Tags = tagOpts.computeTags(plan.desired.Tags)
+}
+
+func (p *planner) finish() []*entityPlan {
+	for _, plan := range p.plans {
+		plan.Changes = diffTags(plan.current.Tags, plan.desired.Tags)
+	}
+	return p.plans
+}
+
+// plan computes the tag changes for every entity matched by the file
+func (c *Client) plan(ctx context.Context, file *applyFile) ([]*entityPlan, error) {
+	p := newPlanner()
+
+	for i := range file.Entities {
+		entry := &file.Entities[i]
+		entities, err := c.resolve(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entity := range entities {
+			p.add(entity, entry.tagOptions())
+		}
+	}
+
+	return p.finish(), nil
+}
+
+func (c *Client) printPlan(plans []*entityPlan) {
+	var entities, added, changed, removed int
+	for _, p := range plans {
+		if len(p.Changes) == 0 {
+			continue
+		}
+		entities++
+
+		_, _ = fmt.Fprintf(c.output, "~ entity %s (%s", p.ID, p.Type)
+		if p.Name != "" {
+			_, _ = fmt.Fprintf(c.output, " %s", p.Name)
+		}
+		_, _ = fmt.Fprintln(c.output, ")")
+
+		for _, change := range p.Changes {
+			switch change.Action {
+			case tagChangeAdd:
+				added++
+			case tagChangeUpdate:
+				changed++
+			case tagChangeRemove:
+				removed++
+			}
+			_, _ = fmt.Fprint(c.output, "    ")
+			printTagChanges(c.output, []TagChange{change})
+		}
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Plan: %d of %d entities to update, %d tags to add, %d to change, %d to remove\n",
+		entities, len(plans), added, changed, removed)
+}
+
+// Apply reconciles entity tags with the desired state described in the apply file
+func (c *Client) Apply(ctx context.Context) error {
+	file, err := loadApplyFile(c.opts.File)
+	if err != nil {
+		return err
+	}
+
+	plans, err := c.plan(ctx, file)
+	if err != nil {
+		return err
+	}
+
+	return c.execute(ctx, plans, nil)
+}
+
+// execute shows the plan, asks for confirmation unless --auto-approve is set
+// and updates every entity with pending changes. Skipped entities are only
+// reported in the JSON result, text output lists them while planning.
+func (c *Client) execute(ctx context.Context, plans []*entityPlan, skipped []skippedEntity) error {
+	pending := 0
+	for _, p := range plans {
+		if len(p.Changes) > 0 {
+			pending++
+		}
+	}
+
+	if !c.opts.JSON {
+		c.printPlan(plans)
+	}
+
+	if pending > 0 && !c.opts.AutoApprove && !c.opts.DryRun {
+		if err := shared.Approve(c.input, c.prompts, "Do you want to apply these changes?"); err != nil {
+			// The JSON output still shows the plan that was not applied
+			if c.opts.JSON {
+				if printErr := c.printApplyResult(plans, skipped); printErr != nil {
+					return errors.Join(err, printErr)
+				}
+			}
+			return err
+		}
+	}
+
+	failed := 0
+	for _, p := range plans {
+		if len(p.Changes) == 0 {
+			p.Status = "unchanged"
+			continue
+		}
+
+		if err := c.putEntity(ctx, p.desired); err != nil {
+			failed++
+			p.Status = "failed"
+			p.Error = err.Error()
+			if !c.opts.JSON {
+				_, _ = fmt.Fprintf(c.output, "Failed to update entity %s: %v\n", p.ID, err)
+			}
+			continue
+		}
+
+		p.Status = "success"
+		if c.opts.DryRun {
+			p.Status = "dry-run"
+		} else if !c.opts.JSON {
+			_, _ = fmt.Fprintf(c.output, "Entity %s updated successfully\n", p.ID)
+		}
+	}
+
+	if c.opts.JSON {
+		if err := c.printApplyResult(plans, skipped); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errApplyFailed, failed, pending)
+	}
+
+	return nil
+}
+
+func (c *Client) printApplyResult(plans []*entityPlan, skipped []skippedEntity) error {
+	if plans == nil {
+		plans = []*entityPlan{}
+	}
+	jsonData, err := json.Marshal(applyResponse{Plan: plans, Skipped: skipped})
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(c.output, string(jsonData))
+	return nil
+}
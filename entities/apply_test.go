@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+var applyEntities = []Entity{
+	{ID: "e-1", Type: "Host", Name: "web-01", Tags: map[string]*string{"env": stringPtr("staging"), "deprecated": stringPtr("true")}},
+	{ID: "e-2", Type: "Host", Name: "web-02", Tags: map[string]*string{"env": stringPtr("production")}},
+	{ID: "e-3", Type: "Service", Name: "checkout", Tags: map[string]*string{"team": stringPtr("payments")}},
+}
+
+func writeApplyFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tags.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+const testApplyFile = `
+entities:
+  - selector:
+      type: Host
+    tags:
+      env: production
+    removeTags: [deprecated]
+  - id: e-3
+    tags:
+      team: payments
+  - selector:
+      type: Host
+      name: web-02
+    tags:
+      owner: sre
+`
+
+func TestLoadApplyFile(t *testing.T) {
+	file, err := loadApplyFile(writeApplyFile(t, testApplyFile))
+	require.NoError(t, err)
+	require.Len(t, file.Entities, 3)
+	require.Equal(t, "Host", file.Entities[0].Selector.Type)
+	require.Equal(t, []string{"deprecated"}, file.Entities[0].RemoveTags)
+	require.Equal(t, "e-3", file.Entities[1].ID)
+
+	invalid := []string{
+		"entities:\n  - tags:\n      env: production\n",
+		"entities:\n  - id: e-1\n    selector:\n      type: Host\n",
+		"entities:\n  - selector:\n      name: web-01\n",
+		"entities: [",
+	}
+	for _, content := range invalid {
+		_, err := loadApplyFile(writeApplyFile(t, content))
+		require.Error(t, err, content)
+	}
+
+	_, err = loadApplyFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestApplyPlanWithoutApproval(t *testing.T) {
+	server, store := newEntityServer(t, applyEntities)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: writeApplyFile(t, testApplyFile)})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.ErrorIs(t, client.Apply(context.Background()), shared.ErrNotApproved)
+	require.Zero(t, store.putCount())
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "~ entity e-1 (Host web-01)")
+	require.Contains(t, output, "    ~ env=staging -> production")
+	require.Contains(t, output, "    - deprecated=true")
+	require.Contains(t, output, "~ entity e-2 (Host web-02)")
+	require.Contains(t, output, "    + owner=sre")
+	require.NotContains(t, output, "e-3")
+	require.Contains(t, output, "Plan: 2 of 3 entities to update, 1 tags to add, 1 to change, 1 to remove")
+	require.NotContains(t, output, "use --auto-approve")
+}
+
+func TestApplyAutoApprove(t *testing.T) {
+	server, store := newEntityServer(t, applyEntities)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: writeApplyFile(t, testApplyFile), AutoApprove: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Apply(context.Background()))
+	require.Equal(t, 2, store.putCount())
+
+	web01 := store.get("e-1")
+	require.Len(t, web01.Tags, 1)
+	require.Equal(t, "production", *web01.Tags["env"])
+
+	web02 := store.get("e-2")
+	require.Len(t, web02.Tags, 2)
+	require.Equal(t, "sre", *web02.Tags["owner"])
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "Entity e-1 updated successfully")
+	require.Contains(t, output, "Entity e-2 updated successfully")
+
+	// Applying again is a no-op
+	client, err = NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: client.opts.File, AutoApprove: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Apply(context.Background()))
+	require.Equal(t, 2, store.putCount())
+	require.Contains(t, testutil.ReadOutput(t, client.output), "Plan: 0 of 3 entities to update")
+}
+
+func TestApplyDryRun(t *testing.T) {
+	server, store := newEntityServer(t, applyEntities)
+
+	opts := &Options{File: writeApplyFile(t, testApplyFile)}
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Apply(context.Background()))
+	require.Zero(t, store.putCount())
+	require.Contains(t, testutil.ReadOutput(t, client.output), "PUT "+server.URL+"/v1/entities/e-1")
+}
+
+func TestApplyJSON(t *testing.T) {
+	server, _ := newEntityServer(t, applyEntities)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: writeApplyFile(t, testApplyFile), AutoApprove: true, JSON: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Apply(context.Background()))
+
+	var response struct {
+		Plan []entityPlan `json:"plan"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &response))
+	require.Len(t, response.Plan, 3)
+	require.Equal(t, "e-1", response.Plan[0].ID)
+	require.Equal(t, "success", response.Plan[0].Status)
+	require.Len(t, response.Plan[0].Changes, 2)
+	require.Equal(t, "e-3", response.Plan[2].ID)
+	require.Equal(t, "unchanged", response.Plan[2].Status)
+}
+
+func TestApplyJSONWithoutApproval(t *testing.T) {
+	server, store := newEntityServer(t, applyEntities)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: writeApplyFile(t, testApplyFile), JSON: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.ErrorIs(t, client.Apply(context.Background()), shared.ErrNotApproved)
+	require.Zero(t, store.putCount())
+
+	// The output is only the plan, it stays valid JSON
+	var response struct {
+		Plan []entityPlan `json:"plan"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &response))
+	require.Len(t, response.Plan, 3)
+}
+
+func TestApplyMissingEntity(t *testing.T) {
+	server, _ := newEntityServer(t, applyEntities)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: writeApplyFile(t, "entities:\n  - id: e-404\n    tags:\n      env: production\n")})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	err = client.Apply(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "e-404")
+}
@@ -0,0 +1,260 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultCacheTTL is how long the entity cache is used for name resolution before it is ignored
+	DefaultCacheTTL = 24 * time.Hour
+
+	cacheDirName  = "swo-cli"
+	cacheFileName = "entities.json"
+)
+
+var (
+	errCacheMissing = errors.New("entity cache not found, run 'swo entities cache refresh' first")
+	errOffline      = errors.New("entities cannot be changed in offline mode")
+)
+
+// entityCache is the local copy of the entity inventory. Only the fields needed to
+// find entities are kept; attributes are not cached.
+type entityCache struct {
+	APIURL      string    `json:"apiUrl"`
+	UpdatedTime time.Time `json:"updatedTime"`
+	Types       []string  `json:"types"`
+	Entities    []Entity  `json:"entities"`
+}
+
+// DefaultCacheFile returns the path of the entity cache in the user cache directory,
+// which is $XDG_CACHE_HOME on Linux
+func DefaultCacheFile() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find cache directory: %w", err)
+	}
+	return filepath.Join(dir, cacheDirName, cacheFileName), nil
+}
+
+func loadCache(path string) (*entityCache, error) {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errCacheMissing
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entity cache %s: %w", path, err)
+	}
+
+	cache := &entityCache{}
+	if err := json.Unmarshal(content, cache); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling entity cache %s: %w", path, err)
+	}
+	return cache, nil
+}
+
+// saveCache writes the cache to a temporary file first, so concurrent readers
+// never see a partially written cache
+func saveCache(path string, cache *entityCache) error {
+	content, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entity cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entities-*")
+	if err != nil {
+		return fmt.Errorf("failed to write entity cache %s: %w", path, err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write entity cache %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write entity cache %s: %w", path, err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (e *entityCache) fresh(ttl time.Duration, now time.Time) bool {
+	return now.Sub(e.UpdatedTime) < ttl
+}
+
+func (e *entityCache) get(id string) (*Entity, bool) {
+	for i := range e.Entities {
+		if e.Entities[i].ID == id {
+			return &e.Entities[i], true
+		}
+	}
+	return nil, false
+}
+
+// filter returns the cached entities of the given type, matching the API name filter
+func (e *entityCache) filter(entityType string, name string) []Entity {
+	var result []Entity
+	for _, entity := range e.Entities {
+		if entity.Type != entityType {
+			continue
+		}
+		if name != "" && entity.Name != name {
+			continue
+		}
+		result = append(result, entity)
+	}
+	return result
+}
+
+// cached returns the entity cache if one was written for the same API URL. With
+// --offline a missing cache is an error, otherwise the cache is optional.
+func (c *Client) cached() (*entityCache, error) {
+	if c.opts.CacheFile == "" {
+		if c.opts.Offline {
+			return nil, errCacheMissing
+		}
+		return nil, nil
+	}
+
+	if c.cache == nil {
+		cache, err := loadCache(c.opts.CacheFile)
+		if err != nil {
+			if c.opts.Offline {
+				return nil, err
+			}
+			if !errors.Is(err, errCacheMissing) {
+				slog.Warn("Ignoring entity cache", "error", err)
+			}
+			return nil, nil
+		}
+		if cache.APIURL != c.opts.APIURL {
+			if c.opts.Offline {
+				return nil, fmt.Errorf("%w: cache was written for %s", errCacheMissing, cache.APIURL)
+			}
+			return nil, nil
+		}
+		c.cache = cache
+	}
+
+	return c.cache, nil
+}
+
+// freshCache returns the entity cache if it can be used instead of the API for
+// lookups, that is in offline mode or while it is younger than the TTL
+func (c *Client) freshCache() (*entityCache, error) {
+	cache, err := c.cached()
+	if err != nil || cache == nil {
+		return nil, err
+	}
+
+	if c.opts.Offline {
+		if !cache.fresh(c.opts.CacheTTL, time.Now()) {
+			slog.Warn("Entity cache is older than its TTL", "updated", cache.UpdatedTime.Format(time.RFC3339))
+		}
+		return cache, nil
+	}
+
+	if !cache.fresh(c.opts.CacheTTL, time.Now()) {
+		slog.Debug("Entity cache expired, using the API", "updated", cache.UpdatedTime.Format(time.RFC3339))
+		return nil, nil
+	}
+	return cache, nil
+}
+
+// RefreshCache rebuilds the entity cache from all entities of all types
+func (c *Client) RefreshCache(ctx context.Context) error {
+	types, err := c.fetchTypes(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Strings(types)
+
+	cache := &entityCache{APIURL: c.opts.APIURL, UpdatedTime: time.Now().UTC(), Types: types, Entities: []Entity{}}
+	for _, t := range types {
+		err := c.listEntities(ctx, t, "", func(entities []Entity) error {
+			for _, entity := range entities {
+				cache.Entities = append(cache.Entities, Entity{
+					ID:            entity.ID,
+					Type:          entity.Type,
+					Name:          entity.Name,
+					DisplayName:   entity.DisplayName,
+					LastSeenTime:  entity.LastSeenTime,
+					InMaintenance: entity.InMaintenance,
+					Tags:          entity.Tags,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list entities of type %s: %w", t, err)
+		}
+	}
+
+	if err := saveCache(c.opts.CacheFile, cache); err != nil {
+		return err
+	}
+	c.cache = cache
+
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"path":        c.opts.CacheFile,
+			"types":       len(types),
+			"entities":    len(cache.Entities),
+			"updatedTime": cache.UpdatedTime.Format(time.RFC3339),
+		})
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Cached %d entities of %d types in %s\n", len(cache.Entities), len(types), c.opts.CacheFile)
+	return nil
+}
+
+// ClearCache removes the entity cache
+func (c *Client) ClearCache(_ context.Context) error {
+	if err := os.Remove(c.opts.CacheFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove entity cache: %w", err)
+	}
+
+	if !c.opts.JSON {
+		_, _ = fmt.Fprintf(c.output, "Removed %s\n", c.opts.CacheFile)
+	}
+	return nil
+}
+
+// cachedNames returns the sorted distinct names of the cached entities of the
+// given type, or of all types, for shell completion
+func cachedNames(path string, entityType string) []string {
+	cache, err := loadCache(path)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, entity := range cache.Entities {
+		if entity.Name == "" || seen[entity.Name] || (entityType != "" && entity.Type != entityType) {
+			continue
+		}
+		seen[entity.Name] = true
+		names = append(names, entity.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshCache(t *testing.T) {
+	server, _ := newEntityServer(t, lookupEntities)
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.CacheFile = filepath.Join(t.TempDir(), "swo-cli", "entities.json")
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.RefreshCache(context.Background()))
+	require.Contains(t, testutil.ReadOutput(t, client.output), "Cached 4 entities of 2 types")
+
+	cache, err := loadCache(opts.CacheFile)
+	require.NoError(t, err)
+	require.Equal(t, server.URL, cache.APIURL)
+	require.Equal(t, []string{"Host", "Service"}, cache.Types)
+	require.Len(t, cache.Entities, 4)
+
+	require.Equal(t, []string{"db-01", "web-01", "web-02"}, cachedNames(opts.CacheFile, "Host"))
+	require.Equal(t, []string{"web-01"}, cachedNames(opts.CacheFile, "Service"))
+
+	require.NoError(t, client.ClearCache(context.Background()))
+	_, err = os.Stat(opts.CacheFile)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func writeTestCache(t *testing.T, apiURL string, updated time.Time) string {
+	path := filepath.Join(t.TempDir(), "entities.json")
+	require.NoError(t, saveCache(path, &entityCache{
+		APIURL:      apiURL,
+		UpdatedTime: updated,
+		Types:       []string{"Host", "Service"},
+		Entities:    lookupEntities,
+	}))
+	return path
+}
+
+func TestOfflineReads(t *testing.T) {
+	opts := NewOptions()
+	opts.Offline = true
+	opts.CacheFile = writeTestCache(t, "http://offline.invalid", time.Now().Add(-48*time.Hour))
+	opts.Token = "test-token"
+	opts.APIURL = "http://offline.invalid"
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	types, err := client.fetchTypes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"Host", "Service"}, types)
+
+	entities, err := client.fetchEntities(context.Background(), "Host", "")
+	require.NoError(t, err)
+	require.Len(t, entities, 3)
+
+	entity, err := client.getEntity(context.Background(), "e-3")
+	require.NoError(t, err)
+	require.Equal(t, "Service", entity.Type)
+
+	_, err = client.getEntity(context.Background(), "e-9")
+	require.ErrorIs(t, err, errNoMatchingEntity)
+
+	// Stale caches are still served offline
+	opts.Name = "db"
+	require.NoError(t, client.GetEntity(context.Background()))
+	require.Contains(t, testutil.ReadOutput(t, client.output), "e-4")
+
+	require.ErrorIs(t, client.putEntity(context.Background(), entity), errOffline)
+}
+
+func TestOfflineWithoutCache(t *testing.T) {
+	opts := NewOptions()
+	opts.Offline = true
+	opts.CacheFile = filepath.Join(t.TempDir(), "entities.json")
+	opts.Token = "test-token"
+	opts.APIURL = "http://offline.invalid"
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	_, err = client.fetchTypes(context.Background())
+	require.ErrorIs(t, err, errCacheMissing)
+}
+
+func TestFindEntitiesUsesFreshCache(t *testing.T) {
+	server, _ := newEntityServer(t, []Entity{{ID: "e-9", Type: "Host", Name: "web-09"}})
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		apiURL   string
+		updated  time.Time
+		expected string
+	}{
+		{"fresh cache", server.URL, time.Now(), "e-1"},
+		{"expired cache", server.URL, time.Now().Add(-48 * time.Hour), "e-9"},
+		{"cache of another API", "http://other.invalid", time.Now(), "e-9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			opts.Type = "Host"
+			opts.Name = "web"
+			opts.CacheFile = writeTestCache(t, tt.apiURL, tt.updated)
+			opts.Token = "test-token"
+			opts.APIURL = server.URL
+			client, err := NewClient(opts)
+			require.NoError(t, err)
+			client.output = testutil.TempFile(t)
+			client.input = testutil.TempFile(t)
+			client.prompts = testutil.TempFile(t)
+
+			entities, err := client.findEntities(context.Background(), opts.Type, opts.Name)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, entities[0].ID)
+		})
+	}
+}
@@ -13,6 +13,8 @@
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/solarwinds/swo-cli/shared"
 )
@@ -27,13 +29,20 @@
 	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
 	// ErrNoContent indicates an empty response body was received from the API
 	ErrNoContent = errors.New("no content")
+	// ErrEntityModified indicates the entity was updated after the time given by --if-updated-before
+	ErrEntityModified = errors.New("entity was modified after the expected time")
+
+	errNotFound = errors.New("not found")
 )
 
 // Client is an entities client
 type Client struct {
 	opts       *Options
 	httpClient http.Client
+	input      *os.File
 	output     *os.File
+	prompts    *os.File
+	cache      *entityCache
 }
 
 // Entity represents an entity from the SWO API
@@ -64,6 +73,12 @@ type listTypesResponse struct {
 	Types []string `json:"types"`
 }
 
+type updateEntityResponse struct {
+	Status  string      `json:"status"`
+	ID      string      `json:"id"`
+	Changes []TagChange `json:"changes"`
+}
+
 // NewClient creates a new entities client
 func NewClient(opts *Options) (*Client, error) {
 	// Configure logging based on verbose flag
@@ -72,108 +87,78 @@ func NewClient(opts *Options) (*Client, error) {
 	return &Client{
 		httpClient: *http.DefaultClient,
 		opts:       opts,
+		input:      os.Stdin,
 		output:     os.Stdout,
+		prompts:    os.Stderr,
 	}, nil
 }
 
 func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
-	var entitiesEndpoint string
-	var err error
-	params := url.Values{}
+	return c.prepareFilteredListRequest(ctx, c.opts.Type, c.opts.Name, nextPage)
+}
 
-	if nextPage == "" {
-		entitiesEndpoint, err = url.JoinPath(c.opts.APIURL, "v1/entities")
-		if err != nil {
-			return nil, err
-		}
+func (c *Client) prepareFilteredListRequest(ctx context.Context, entityType string, name string, nextPage string) (*http.Request, error) {
+	params := url.Values{}
 
-		// Type is required
-		params.Add("type", c.opts.Type)
+	// Type is required
+	params.Add("type", entityType)
 
-		// Name is optional
-		if c.opts.Name != "" {
-			params.Add("name", c.opts.Name)
-		}
+	// Name is optional
+	if name != "" {
+		params.Add("name", name)
+	}
 
-		params.Add("pageSize", strconv.Itoa(DefaultPageSize))
-	} else {
-		u, err := url.Parse(nextPage)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse nextPage field: %w", err)
-		}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
 
-		entitiesEndpoint, err = url.JoinPath(c.opts.APIURL, u.Path)
-		if err != nil {
-			return nil, err
-		}
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/entities")
+}
 
-		params, err = url.ParseQuery(u.RawQuery)
-		if err != nil {
-			return nil, err
-		}
-	}
+func (c *Client) prepareGetRequest(ctx context.Context, id string) (*http.Request, error) {
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, nil, "", "v1/entities", id)
+}
 
-	entitiesURL, err := url.Parse(entitiesEndpoint)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) prepareUpdateRequest(ctx context.Context, entity *Entity) (*http.Request, error) {
+	// Replace the entity tags with the final tag set
+	entity.Tags = c.opts.computeTags(entity.Tags)
 
-	entitiesURL.RawQuery = params.Encode()
+	return c.preparePutRequest(ctx, entity)
+}
 
-	request, err := http.NewRequestWithContext(ctx, "GET", entitiesURL.String(), nil)
+func (c *Client) preparePutRequest(ctx context.Context, entity *Entity) (*http.Request, error) {
+	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/entities", entity.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
-	request.Header.Add("Accept", "application/json")
-
-	return request, nil
-}
-
-func (c *Client) prepareGetRequest(ctx context.Context) (*http.Request, error) {
-	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/entities", c.opts.ID)
+	jsonData, err := json.Marshal(entity)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to marshal entity data: %w", err)
 	}
 
-	request, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	request, err := http.NewRequestWithContext(ctx, "PUT", endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, err
 	}
 
 	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
+	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("Accept", "application/json")
 
 	return request, nil
 }
 
-func (c *Client) prepareUpdateRequest(ctx context.Context, entity *Entity) (*http.Request, error) {
-	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/entities", c.opts.ID)
+func (c *Client) prepareDeleteRequest(ctx context.Context, id string) (*http.Request, error) {
+	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/entities", id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update the entity tags with the new ones
-	if entity.Tags == nil {
-		entity.Tags = make(map[string]*string)
-	}
-	for key, value := range c.opts.Tags {
-		entity.Tags[key] = &value
-	}
-
-	jsonData, err := json.Marshal(entity)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal entity data: %w", err)
-	}
-
-	request, err := http.NewRequestWithContext(ctx, "PUT", endpoint, bytes.NewBuffer(jsonData))
+	request, err := http.NewRequestWithContext(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
-	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("Accept", "application/json")
 
 	return request, nil
@@ -197,6 +182,11 @@ func (c *Client) prepareListTypesRequest(ctx context.Context) (*http.Request, er
 }
 
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	if c.opts.DryRun && req.Method != http.MethodGet {
+		slog.Debug("Dry run, skipping HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+		return nil, shared.PrintDryRunRequest(c.output, req)
+	}
+
 	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
 
 	response, err := c.httpClient.Do(req) //nolint:gosec
@@ -219,6 +209,9 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 
 	slog.Debug("Response body", "length_bytes", len(content))
 
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %w: %d, response body: %s", errNotFound, ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
 	if response.StatusCode < 200 || response.StatusCode > 299 {
 		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
 	}
@@ -325,12 +318,20 @@ func (c *Client) printTypes(types []string) error {
 	return nil
 }
 
-// ListEntities retrieves and displays entities
-func (c *Client) ListEntities(ctx context.Context) error {
+// listEntities walks all pages of entities of the given type, calling fn for each page
+func (c *Client) listEntities(ctx context.Context, entityType string, name string, fn func([]Entity) error) error {
+	if c.opts.Offline {
+		cache, err := c.cached()
+		if err != nil {
+			return err
+		}
+		return fn(cache.filter(entityType, name))
+	}
+
 	var nextPage string
 
 	for {
-		request, err := c.prepareListRequest(ctx, nextPage)
+		request, err := c.prepareFilteredListRequest(ctx, entityType, name, nextPage)
 		if err != nil {
 			return fmt.Errorf("error while preparing http request to SWO: %w", err)
 		}
@@ -350,9 +351,8 @@ func (c *Client) ListEntities(ctx context.Context) error {
 			return fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
 		}
 
-		err = c.printEntities(response.Entities)
-		if err != nil {
-			return fmt.Errorf("failed to print entities: %w", err)
+		if err = fn(response.Entities); err != nil {
+			return err
 		}
 
 		if response.NextPage == "" {
@@ -365,96 +365,265 @@ func (c *Client) ListEntities(ctx context.Context) error {
 	return nil
 }
 
-// GetEntity retrieves and displays a single entity by ID
-func (c *Client) GetEntity(ctx context.Context) error {
-	request, err := c.prepareGetRequest(ctx)
+// fetchEntities collects all entities of the given type, optionally filtered by name
+func (c *Client) fetchEntities(ctx context.Context, entityType string, name string) ([]Entity, error) {
+	var result []Entity
+	err := c.listEntities(ctx, entityType, name, func(entities []Entity) error {
+		result = append(result, entities...)
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getEntity retrieves a single entity by ID
+func (c *Client) getEntity(ctx context.Context, id string) (*Entity, error) {
+	if c.opts.Offline {
+		cache, err := c.cached()
+		if err != nil {
+			return nil, err
+		}
+		entity, ok := cache.get(id)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not in the entity cache", errNoMatchingEntity, id)
+		}
+		return entity, nil
+	}
+
+	request, err := c.prepareGetRequest(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
 	}
 
 	content, err := c.doRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(content) == 0 {
-		return ErrNoContent
+		return nil, ErrNoContent
 	}
 
 	var entity Entity
 	err = json.Unmarshal(content, &entity)
 	if err != nil {
-		return fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+		return nil, fmt.Errorf("error while unmarshaling entity from SWO: %w", err)
+	}
+
+	return &entity, nil
+}
+
+// putEntity stores the entity as it is
+func (c *Client) putEntity(ctx context.Context, entity *Entity) error {
+	if c.opts.Offline {
+		return errOffline
+	}
+
+	request, err := c.preparePutRequest(ctx, entity)
+	if err != nil {
+		return fmt.Errorf("error while preparing update request to SWO: %w", err)
 	}
 
-	return c.printEntity(&entity)
+	// Empty content is acceptable for updates
+	_, err = c.doRequest(request)
+	return err
+}
+
+// deleteEntity removes the entity from SWO
+func (c *Client) deleteEntity(ctx context.Context, id string) error {
+	if c.opts.Offline {
+		return errOffline
+	}
+
+	request, err := c.prepareDeleteRequest(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error while preparing delete request to SWO: %w", err)
+	}
+
+	// Empty content is acceptable for deletes
+	_, err = c.doRequest(request)
+	return err
+}
+
+// ListEntities retrieves and displays entities
+func (c *Client) ListEntities(ctx context.Context) error {
+	err := c.listEntities(ctx, c.opts.Type, c.opts.Name, func(entities []Entity) error {
+		if err := c.printEntities(entities); err != nil {
+			return fmt.Errorf("failed to print entities: %w", err)
+		}
+		return nil
+	})
+	return err
+}
+
+// GetEntity retrieves and displays a single entity by ID or name
+func (c *Client) GetEntity(ctx context.Context) error {
+	if err := c.resolveID(ctx, false); err != nil {
+		return err
+	}
+
+	entity, err := c.getEntity(ctx, c.opts.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.printEntity(entity)
 }
 
 // UpdateEntity updates entity tags
 func (c *Client) UpdateEntity(ctx context.Context) error {
+	if err := c.resolveID(ctx, true); err != nil {
+		return err
+	}
+
 	// First, get the current entity
-	getRequest, err := c.prepareGetRequest(ctx)
+	entity, err := c.getEntity(ctx, c.opts.ID)
 	if err != nil {
-		return fmt.Errorf("error while preparing get request to SWO: %w", err)
+		return err
 	}
 
-	content, err := c.doRequest(getRequest)
-	if err != nil {
+	if err = c.checkUpdatedBefore(entity); err != nil {
 		return err
 	}
 
-	if len(content) == 0 {
-		return ErrNoContent
+	changes := diffTags(entity.Tags, c.opts.computeTags(entity.Tags))
+	if !c.opts.JSON {
+		printTagChanges(c.output, changes)
 	}
 
-	var entity Entity
-	err = json.Unmarshal(content, &entity)
+	status := "unchanged"
+	if len(changes) > 0 {
+		before, err := json.MarshalIndent(entity, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal entity data: %w", err)
+		}
+
+		// Now update with the new tags
+		updateRequest, err := c.prepareUpdateRequest(ctx, entity)
+		if err != nil {
+			return fmt.Errorf("error while preparing update request to SWO: %w", err)
+		}
+
+		if c.opts.DryRun {
+			if err = c.printEntityDiff(before, entity); err != nil {
+				return err
+			}
+		}
+
+		// Use doRequest for consistency - empty content is acceptable for updates
+		_, err = c.doRequest(updateRequest)
+		if err != nil {
+			return err
+		}
+		status = "success"
+		if c.opts.DryRun {
+			status = "dry-run"
+		}
+	}
+
+	if !c.opts.JSON {
+		switch status {
+		case "success":
+			_, _ = fmt.Fprintf(c.output, "Entity %s updated successfully\n", c.opts.ID)
+		case "dry-run":
+			_, _ = fmt.Fprintf(c.output, "Dry run: entity %s was not updated\n", c.opts.ID)
+		}
+		return nil
+	}
+
+	jsonData, err := json.Marshal(updateEntityResponse{Status: status, ID: c.opts.ID, Changes: changes})
 	if err != nil {
-		return fmt.Errorf("error while unmarshaling entity from SWO: %w", err)
+		return err
 	}
+	_, _ = fmt.Fprintln(c.output, string(jsonData))
+
+	return nil
+}
 
-	// Now update with the new tags
-	updateRequest, err := c.prepareUpdateRequest(ctx, &entity)
+// printEntityDiff writes a line diff between the indented JSON of the entity before
+// and after the update. Colors are used when the output is a terminal.
+func (c *Client) printEntityDiff(before []byte, after *Entity) error {
+	afterData, err := json.MarshalIndent(after, "", "  ")
 	if err != nil {
-		return fmt.Errorf("error while preparing update request to SWO: %w", err)
+		return fmt.Errorf("failed to marshal entity data: %w", err)
 	}
 
-	// Use doRequest for consistency - empty content is acceptable for updates
-	_, err = c.doRequest(updateRequest)
+	diff := shared.DiffLines(strings.Split(string(before), "\n"), strings.Split(string(afterData), "\n"))
+	_, _ = fmt.Fprintf(c.output, "--- entity %s (current)\n+++ entity %s (updated)\n", after.ID, after.ID)
+	shared.WriteDiff(c.output, diff, shared.IsTerminal(c.output))
+	_, _ = fmt.Fprintln(c.output)
+
+	return nil
+}
+
+// checkUpdatedBefore guards against overwriting changes made by someone else since
+// the caller last looked at the entity. The entities API has no conditional PUT, so
+// this is a best-effort check of the entity fetched just before the update; a change
+// made between that fetch and the PUT is still overwritten.
+func (c *Client) checkUpdatedBefore(entity *Entity) error {
+	if c.opts.IfUpdatedBefore == "" {
+		return nil
+	}
+
+	limit, err := time.Parse(time.RFC3339, c.opts.IfUpdatedBefore)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %s", errInvalidTimestamp, c.opts.IfUpdatedBefore)
 	}
 
-	if !c.opts.JSON {
-		_, _ = fmt.Fprintf(c.output, "Entity %s updated successfully\n", c.opts.ID)
-	} else {
-		_, _ = fmt.Fprintf(c.output, `{"status":"success","id":"%s"}\n`, c.opts.ID)
+	updated, err := time.Parse(time.RFC3339, entity.UpdatedTime)
+	if err != nil {
+		return fmt.Errorf("%w: entity %s has no valid updatedTime", ErrEntityModified, entity.ID)
+	}
+
+	if updated.After(limit) {
+		return fmt.Errorf("%w: entity %s was updated at %s", ErrEntityModified, entity.ID, entity.UpdatedTime)
 	}
 
 	return nil
 }
 
-// ListTypes retrieves and displays all available entity types
-func (c *Client) ListTypes(ctx context.Context) error {
+// fetchTypes retrieves all available entity types
+func (c *Client) fetchTypes(ctx context.Context) ([]string, error) {
+	if c.opts.Offline {
+		cache, err := c.cached()
+		if err != nil {
+			return nil, err
+		}
+		return cache.Types, nil
+	}
+
 	request, err := c.prepareListTypesRequest(ctx)
 	if err != nil {
-		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
 	}
 
 	content, err := c.doRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(content) == 0 {
-		return ErrNoContent
+		return nil, ErrNoContent
 	}
 
 	var response listTypesResponse
 	err = json.Unmarshal(content, &response)
 	if err != nil {
-		return fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return response.Types, nil
+}
+
+// ListTypes retrieves and displays all available entity types
+func (c *Client) ListTypes(ctx context.Context) error {
+	types, err := c.fetchTypes(ctx)
+	if err != nil {
+		return err
 	}
 
-	return c.printTypes(response.Types)
+	return c.printTypes(types)
 }
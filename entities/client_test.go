@@ -3,14 +3,18 @@
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/solarwinds/swo-cli/internal/testutil"
 	"github.com/solarwinds/swo-cli/shared"
 	"github.com/stretchr/testify/require"
 )
@@ -255,7 +259,7 @@ func TestPrepareGetRequest(t *testing.T) {
 	client, err := NewClient(opts)
 	require.NoError(t, err)
 
-	request, err := client.prepareGetRequest(context.Background())
+	request, err := client.prepareGetRequest(context.Background(), "e-1234567890")
 	require.NoError(t, err)
 
 	expectedURL := "https://api.example.com/v1/entities/e-1234567890"
@@ -654,3 +658,329 @@ func TestErrorHandling(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "401")
 }
+
+func TestUpdateEntityRemoveAndReplaceTags(t *testing.T) {
+	var putEntity *Entity
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(testEntities[0]); err != nil {
+				t.Errorf("Failed to encode response: %v", err)
+			}
+		case "PUT":
+			putEntity = &Entity{}
+			if err := json.NewDecoder(r.Body).Decode(putEntity); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("remove tag", func(t *testing.T) {
+		putEntity = nil
+		opts := NewOptions()
+		opts.ID = "e-1234567890"
+		opts.Token = "test-token"
+		opts.APIURL = server.URL
+		opts.RemoveTags = []string{"team"}
+
+		client, err := NewClient(opts)
+		require.NoError(t, err)
+		client.output = testutil.TempFile(t)
+
+		require.NoError(t, client.UpdateEntity(context.Background()))
+		require.NotNil(t, putEntity)
+		require.Len(t, putEntity.Tags, 1)
+		require.Equal(t, "production", *putEntity.Tags["environment"])
+
+		output := testutil.ReadOutput(t, client.output)
+		require.Contains(t, output, "- team=backend")
+		require.Contains(t, output, "Entity e-1234567890 updated successfully")
+	})
+
+	t.Run("replace tags", func(t *testing.T) {
+		putEntity = nil
+		opts := NewOptions()
+		opts.ID = "e-1234567890"
+		opts.Token = "test-token"
+		opts.APIURL = server.URL
+		opts.Tags = map[string]string{"owner": "sre"}
+		opts.ReplaceTags = true
+		opts.JSON = true
+
+		client, err := NewClient(opts)
+		require.NoError(t, err)
+		client.output = testutil.TempFile(t)
+
+		require.NoError(t, client.UpdateEntity(context.Background()))
+		require.NotNil(t, putEntity)
+		require.Len(t, putEntity.Tags, 1)
+		require.Equal(t, "sre", *putEntity.Tags["owner"])
+
+		var response updateEntityResponse
+		require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &response))
+		require.Equal(t, "success", response.Status)
+		require.Len(t, response.Changes, 3)
+	})
+
+	t.Run("no changes skips update", func(t *testing.T) {
+		putEntity = nil
+		opts := NewOptions()
+		opts.ID = "e-1234567890"
+		opts.Token = "test-token"
+		opts.APIURL = server.URL
+		opts.Tags = map[string]string{"team": "backend"}
+
+		client, err := NewClient(opts)
+		require.NoError(t, err)
+		client.output = testutil.TempFile(t)
+
+		require.NoError(t, client.UpdateEntity(context.Background()))
+		require.Nil(t, putEntity)
+		require.Contains(t, testutil.ReadOutput(t, client.output), "No tag changes")
+	})
+}
+
+func TestUpdateEntityIfUpdatedBefore(t *testing.T) {
+	putCalled := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(testEntities[0]); err != nil {
+				t.Errorf("Failed to encode response: %v", err)
+			}
+		case "PUT":
+			putCalled = true
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name            string
+		ifUpdatedBefore string
+		expectedErr     error
+	}{
+		{"entity not modified since", "2024-01-02T00:00:00Z", nil},
+		{"entity modified since", "2024-01-01T12:00:00Z", ErrEntityModified},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			putCalled = false
+			opts := NewOptions()
+			opts.ID = "e-1234567890"
+			opts.Token = "test-token"
+			opts.APIURL = server.URL
+			opts.Tags = map[string]string{"newTag": "newValue"}
+			opts.IfUpdatedBefore = tc.ifUpdatedBefore
+
+			client, err := NewClient(opts)
+			require.NoError(t, err)
+			client.output = testutil.TempFile(t)
+
+			err = client.UpdateEntity(context.Background())
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.False(t, putCalled)
+			} else {
+				require.NoError(t, err)
+				require.True(t, putCalled)
+			}
+		})
+	}
+}
+
+func TestUpdateEntityDryRun(t *testing.T) {
+	putCalled := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(testEntities[0]); err != nil {
+				t.Errorf("Failed to encode response: %v", err)
+			}
+		case "PUT":
+			putCalled = true
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.ID = "e-1234567890"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	opts.DryRun = true
+	opts.Tags = map[string]string{"environment": "staging"}
+
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.UpdateEntity(context.Background()))
+	require.False(t, putCalled)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "~ environment=production -> staging")
+	require.Contains(t, output, `-     "environment": "production",`)
+	require.Contains(t, output, `+     "environment": "staging",`)
+	require.Contains(t, output, "PUT "+server.URL+"/v1/entities/e-1234567890")
+	require.Contains(t, output, "Authorization: Bearer <redacted>")
+	require.NotContains(t, output, "test-token")
+	require.Contains(t, output, "Dry run: entity e-1234567890 was not updated")
+}
+
+// entityStore is an in-memory fake of the entities API used by tests of
+// commands that combine list, get and update requests
+type entityStore struct {
+	mu       sync.Mutex
+	entities map[string]Entity
+	order    []string
+	puts     []Entity
+	deletes  []string
+	lists    int
+	pageSize int
+}
+
+func newEntityServer(t *testing.T, entities []Entity) (*httptest.Server, *entityStore) {
+	store := &entityStore{entities: make(map[string]Entity), pageSize: DefaultPageSize}
+	for _, entity := range entities {
+		store.entities[entity.ID] = entity
+		store.order = append(store.order, entity.ID)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		if r.URL.Path == "/v1/metadata/entities/types" {
+			response := listTypesResponse{Types: []string{}}
+			seen := make(map[string]bool)
+			for _, key := range store.order {
+				if entityType := store.entities[key].Type; !seen[entityType] {
+					seen[entityType] = true
+					response.Types = append(response.Types, entityType)
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(response); err != nil {
+				t.Errorf("Failed to encode response: %v", err)
+			}
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/v1/entities")
+		id = strings.TrimPrefix(id, "/")
+
+		switch {
+		case r.Method == "GET" && id == "":
+			store.lists++
+			var matched []Entity
+			for _, key := range store.order {
+				entity := store.entities[key]
+				if entity.Type != r.URL.Query().Get("type") {
+					continue
+				}
+				if name := r.URL.Query().Get("name"); name != "" && entity.Name != name {
+					continue
+				}
+				matched = append(matched, entity)
+			}
+
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			end := min(offset+store.pageSize, len(matched))
+			response := listEntitiesResponse{Entities: matched[offset:end]}
+			if end < len(matched) {
+				query := r.URL.Query()
+				query.Set("offset", strconv.Itoa(end))
+				response.NextPage = "/v1/entities?" + query.Encode()
+			}
+			if response.Entities == nil {
+				response.Entities = []Entity{}
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(response); err != nil {
+				t.Errorf("Failed to encode response: %v", err)
+			}
+		case r.Method == "GET":
+			entity, ok := store.entities[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(entity); err != nil {
+				t.Errorf("Failed to encode response: %v", err)
+			}
+		case r.Method == "PUT":
+			if _, ok := store.entities[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var entity Entity
+			if err := json.NewDecoder(r.Body).Decode(&entity); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+			}
+			store.entities[id] = entity
+			store.puts = append(store.puts, entity)
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == "DELETE":
+			if _, ok := store.entities[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(store.entities, id)
+			for i, key := range store.order {
+				if key == id {
+					store.order = append(store.order[:i], store.order[i+1:]...)
+					break
+				}
+			}
+			store.deletes = append(store.deletes, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, store
+}
+
+func (s *entityStore) get(id string) Entity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entities[id]
+}
+
+func (s *entityStore) putCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.puts)
+}
+
+func TestListEntitiesPagination(t *testing.T) {
+	var entities []Entity
+	for i := 0; i < 5; i++ {
+		entities = append(entities, Entity{ID: fmt.Sprintf("e-%d", i), Type: "Host", Name: fmt.Sprintf("host-%d", i)})
+	}
+	server, store := newEntityServer(t, entities)
+	store.pageSize = 2
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Type: "Host"})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	result, err := client.fetchEntities(context.Background(), "Host", "")
+	require.NoError(t, err)
+	require.Equal(t, entities, result)
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"github.com/solarwinds/swo-cli/shared"
 	"github.com/urfave/cli/v2"
 )
 
@@ -9,6 +10,17 @@ func NewEntitiesCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "entities",
 		Usage: "Retrieve and manage entities from SolarWinds Observability",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "offline",
+				Usage: "Serve list, get, list-types and summary from the local entity cache without calling the API",
+			},
+			&cli.StringFlag{
+				Name:  "cache-ttl",
+				Usage: "How long the local entity cache is used for name lookups, e.g. 1h or 7d",
+				Value: shared.FormatDuration(DefaultCacheTTL),
+			},
+		},
 		Subcommands: []*cli.Command{
 			{
 				Name:   "list",
@@ -34,14 +46,25 @@ func NewEntitiesCommand() *cli.Command {
 				},
 			},
 			{
-				Name:   "get",
-				Usage:  "Get entity by ID",
-				Action: runGet,
+				Name:         "get",
+				Usage:        "Get entity by ID or name",
+				ArgsUsage:    "[NAME]",
+				Action:       runGet,
+				BashComplete: completeEntityNames,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
-						Name:     "id",
-						Usage:    "Entity ID (required)",
-						Required: true,
+						Name:  "id",
+						Usage: "Entity ID",
+					},
+					&cli.StringFlag{
+						Name:    "name",
+						Aliases: []string{"n"},
+						Usage:   "Entity name, partial and case-insensitive matches are accepted",
+					},
+					&cli.StringFlag{
+						Name:    "type",
+						Aliases: []string{"t"},
+						Usage:   "Entity type to search by name, all types are searched if not set",
 					},
 					&cli.BoolFlag{
 						Name:    "json",
@@ -51,21 +74,274 @@ func NewEntitiesCommand() *cli.Command {
 				},
 			},
 			{
-				Name:   "update",
-				Usage:  "Update entity tags",
-				Action: runUpdate,
+				Name:   "related",
+				Usage:  "Walk the relationships of an entity",
+				Action: runRelated,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "id",
-						Aliases:  []string{"id"},
 						Usage:    "Entity ID (required)",
 						Required: true,
 					},
+					&cli.IntFlag{
+						Name:    "depth",
+						Aliases: []string{"d"},
+						Usage:   "How many relationship levels to follow",
+						Value:   1,
+					},
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"o"},
+						Usage:   "Output format: tree, dot (Graphviz) or json",
+						Value:   FormatTree,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format, same as --format json",
+					},
+				},
+			},
+			{
+				Name:         "update",
+				Usage:        "Update entity tags",
+				ArgsUsage:    "[NAME]",
+				Action:       runUpdate,
+				BashComplete: completeEntityNames,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "id",
+						Usage: "Entity ID",
+					},
+					&cli.StringFlag{
+						Name:    "name",
+						Aliases: []string{"n"},
+						Usage:   "Exact entity name, a partial match has to be confirmed",
+					},
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Entity type to search by name, all types are searched if not set",
+					},
 					&cli.StringSliceFlag{
 						Name:    "tag",
 						Aliases: []string{"t"},
 						Usage:   "Tag in key=value format (can be specified multiple times)",
 					},
+					&cli.StringSliceFlag{
+						Name:  "remove-tag",
+						Usage: "Tag key to remove (can be specified multiple times)",
+					},
+					&cli.BoolFlag{
+						Name:  "replace-tags",
+						Usage: "Replace the whole tag set instead of merging into it",
+					},
+					&cli.StringFlag{
+						Name:  "tag-file",
+						Usage: "Path to a YAML file with tags in key: value format",
+					},
+					&cli.StringFlag{
+						Name:  "if-updated-before",
+						Usage: "Only update if the entity was not modified after this RFC3339 time (e.g. its current updatedTime). Best-effort, checked client-side just before the update",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:   "apply",
+				Usage:  "Reconcile entity tags with the desired state described in a YAML file",
+				Action: runApply,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "file",
+						Aliases:  []string{"f"},
+						Usage:    "Path to the YAML file with desired entity tags (required)",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "auto-approve",
+						Usage: "Apply the plan without asking for confirmation",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:   "export",
+				Usage:  "Export entities with their tags and attributes as JSON lines",
+				Action: runExport,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "type",
+						Aliases:  []string{"t"},
+						Usage:    "Type of entities to export (required)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "name",
+						Aliases: []string{"n"},
+						Usage:   "Filter entities by name",
+					},
+					&cli.StringFlag{
+						Name:    "out",
+						Aliases: []string{"o"},
+						Usage:   "Path of the snapshot file, standard output if not set",
+					},
+				},
+			},
+			{
+				Name:   "import",
+				Usage:  "Restore entity tags from a snapshot created by export",
+				Action: runImport,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "file",
+						Aliases:  []string{"f"},
+						Usage:    "Path to the snapshot file (required)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "match",
+						Usage: "How to find live entities: by 'id' or by type and 'name'",
+						Value: MatchByID,
+					},
+					&cli.BoolFlag{
+						Name:  "merge",
+						Usage: "Merge snapshot tags into the current tags instead of replacing them",
+					},
+					&cli.BoolFlag{
+						Name:  "auto-approve",
+						Usage: "Apply the plan without asking for confirmation",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "diff",
+				Usage:     "Compare two snapshots, or a snapshot with the live inventory",
+				ArgsUsage: "SNAPSHOT [SNAPSHOT]",
+				Action:    runDiff,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "type",
+						Aliases: []string{"t"},
+						Usage:   "Only compare entities of this type",
+					},
+					&cli.StringFlag{
+						Name:  "match",
+						Usage: "How to pair entities: by 'id' or by type and 'name' (e.g. across organizations)",
+						Value: MatchByID,
+					},
+					&cli.BoolFlag{
+						Name:  "exit-code",
+						Usage: "Exit with an error when the inventories differ",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			NewMaintenanceCommand(),
+			newCacheCommand(),
+			{
+				Name:      "describe-type",
+				Usage:     "Show the attributes and tag keys of an entity type",
+				ArgsUsage: "TYPE",
+				Action:    runDescribeType,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "type",
+						Aliases: []string{"t"},
+						Usage:   "Entity type, can also be given as argument",
+					},
+					&cli.IntFlag{
+						Name:  "sample",
+						Usage: "How many entities are sampled to infer attributes and tag keys",
+						Value: DefaultSampleSize,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:   "summary",
+				Usage:  "Count entities per type, in maintenance and not seen recently",
+				Action: runSummary,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "type",
+						Aliases: []string{"t"},
+						Usage:   "Only count entities of this type, all types are counted if not set",
+					},
+					&cli.StringFlag{
+						Name:  "stale-after",
+						Usage: "Entities not seen for longer than this are stale, e.g. 12h or 7d",
+						Value: shared.FormatDuration(DefaultStaleAfter),
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:   "stale",
+				Usage:  "List entities not seen recently, and optionally delete or tag them",
+				Action: runStale,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "type",
+						Aliases:  []string{"t"},
+						Usage:    "Entity type (required)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "name",
+						Aliases: []string{"n"},
+						Usage:   "Filter entities by name",
+					},
+					&cli.StringFlag{
+						Name:  "older-than",
+						Usage: "Entities not seen for longer than this are stale, e.g. 12h or 7d",
+						Value: shared.FormatDuration(DefaultStaleAfter),
+					},
+					&cli.BoolFlag{
+						Name:  "include-never-seen",
+						Usage: "Also treat entities without a last seen time as stale",
+					},
+					&cli.BoolFlag{
+						Name:  "delete",
+						Usage: "Delete the stale entities",
+					},
+					&cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "Tag the stale entities with key=value instead (can be specified multiple times)",
+					},
+					&cli.IntFlag{
+						Name:  "concurrency",
+						Usage: "How many entities are deleted or tagged at the same time",
+						Value: DefaultConcurrency,
+					},
+					&cli.BoolFlag{
+						Name:  "auto-approve",
+						Usage: "Skip the confirmation prompt",
+					},
 					&cli.BoolFlag{
 						Name:    "json",
 						Aliases: []string{"j"},
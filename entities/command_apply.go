@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/urfave/cli/v2"
+)
+
+func runApply(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.File = ctx.String("file")
+	opts.AutoApprove = ctx.Bool("auto-approve")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForApply(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Apply(context.Background())
+}
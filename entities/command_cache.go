@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/urfave/cli/v2"
+)
+
+func newCacheCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "Manage the local entity cache used for name lookups, completion and --offline",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "refresh",
+				Usage:  "Rebuild the entity cache from all entities of all types",
+				Action: runCacheRefresh,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:   "clear",
+				Usage:  "Remove the entity cache",
+				Action: runCacheClear,
+			},
+		},
+	}
+}
+
+// setCacheOptions points the options at the entity cache. The cache is opt-in: it
+// is only used once it was written by 'entities cache refresh'.
+func setCacheOptions(ctx *cli.Context, opts *Options) error {
+	path, err := DefaultCacheFile()
+	if err != nil {
+		return err
+	}
+	opts.CacheFile = path
+	opts.Offline = ctx.Bool("offline")
+
+	if ttl := ctx.String("cache-ttl"); ttl != "" {
+		d, err := shared.ParseDuration(ttl)
+		if err != nil {
+			return fmt.Errorf("invalid cache TTL: %w", err)
+		}
+		opts.CacheTTL = d
+	}
+
+	return nil
+}
+
+// completeEntityNames prints the cached entity names for shell completion. The API
+// is never called, so completion stays fast.
+func completeEntityNames(ctx *cli.Context) {
+	if ctx.NArg() > 0 {
+		return
+	}
+
+	path, err := DefaultCacheFile()
+	if err != nil {
+		return
+	}
+
+	for _, name := range cachedNames(path, ctx.String("type")) {
+		_, _ = fmt.Fprintln(ctx.App.Writer, name)
+	}
+}
+
+func newCacheClient(ctx *cli.Context) (*Client, error) {
+	opts := NewOptions()
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	path, err := DefaultCacheFile()
+	if err != nil {
+		return nil, err
+	}
+	opts.CacheFile = path
+
+	return NewClient(opts)
+}
+
+func runCacheRefresh(ctx *cli.Context) error {
+	client, err := newCacheClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.RefreshCache(context.Background())
+}
+
+func runCacheClear(ctx *cli.Context) error {
+	client, err := newCacheClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.ClearCache(context.Background())
+}
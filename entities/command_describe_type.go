@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/urfave/cli/v2"
+)
+
+func runDescribeType(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Type = ctx.String("type")
+	if opts.Type == "" {
+		opts.Type = ctx.Args().First()
+	}
+	opts.Sample = ctx.Int("sample")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForDescribeType(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.DescribeType(context.Background())
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/urfave/cli/v2"
+)
+
+func runDiff(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Snapshots = ctx.Args().Slice()
+	opts.Type = ctx.String("type")
+	opts.Match = ctx.String("match")
+	opts.ExitCode = ctx.Bool("exit-code")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForDiff(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Diff(context.Background())
+}
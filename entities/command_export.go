@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/urfave/cli/v2"
+)
+
+func runExport(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Type = ctx.String("type")
+	opts.Name = ctx.String("name")
+	opts.Out = ctx.String("out")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := setCacheOptions(ctx, opts); err != nil {
+		return err
+	}
+
+	if err := opts.ValidateForExport(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Export(context.Background())
+}
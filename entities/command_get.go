@@ -10,11 +10,20 @@
 func runGet(ctx *cli.Context) error {
 	opts := NewOptions()
 	opts.ID = ctx.String("id")
+	opts.Name = ctx.String("name")
+	if opts.Name == "" {
+		opts.Name = ctx.Args().First()
+	}
+	opts.Type = ctx.String("type")
 	opts.JSON = ctx.Bool("json")
 	opts.Verbose = ctx.Bool(config.VerboseContextKey)
 	opts.Token = ctx.String(config.TokenContextKey)
 	opts.APIURL = ctx.String(config.APIURLContextKey)
 
+	if err := setCacheOptions(ctx, opts); err != nil {
+		return err
+	}
+
 	if err := opts.ValidateForGet(); err != nil {
 		return err
 	}
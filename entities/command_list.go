@@ -16,6 +16,10 @@ func runList(ctx *cli.Context) error {
 	opts.Token = ctx.String(config.TokenContextKey)
 	opts.APIURL = ctx.String(config.APIURLContextKey)
 
+	if err := setCacheOptions(ctx, opts); err != nil {
+		return err
+	}
+
 	if err := opts.ValidateForList(); err != nil {
 		return err
 	}
@@ -14,6 +14,10 @@ func runListTypes(ctx *cli.Context) error {
 	opts.Token = ctx.String(config.TokenContextKey)
 	opts.APIURL = ctx.String(config.APIURLContextKey)
 
+	if err := setCacheOptions(ctx, opts); err != nil {
+		return err
+	}
+
 	client, err := NewClient(opts)
 	if err != nil {
 		return err
@@ -0,0 +1,267 @@
+package entities
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/urfave/cli/v2"
+)
+
+func maintenanceSelectorFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "id",
+			Usage: "Entity ID (can be specified multiple times)",
+		},
+		&cli.StringFlag{
+			Name:    "type",
+			Aliases: []string{"t"},
+			Usage:   "Select all entities of this type instead of --id",
+		},
+		&cli.StringFlag{
+			Name:    "name",
+			Aliases: []string{"n"},
+			Usage:   "Select entities of the given type by name",
+		},
+		&cli.BoolFlag{
+			Name:    "json",
+			Aliases: []string{"j"},
+			Usage:   "Output in JSON format",
+		},
+	}
+}
+
+func autoApproveFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "auto-approve",
+		Usage: "Change the maintenance of more than one entity without asking for confirmation",
+	}
+}
+
+// NewMaintenanceCommand creates the maintenance command, which is available both
+// as 'swo entities maintenance' and as the top-level 'swo maintenance'
+func NewMaintenanceCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "maintenance",
+		Usage: "Manage maintenance mode of entities",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "start",
+				Usage:  "Put entities into maintenance mode",
+				Action: runMaintenanceStart,
+				Flags: append(maintenanceSelectorFlags(),
+					&cli.StringFlag{
+						Name:  "duration",
+						Usage: "How long the maintenance should last, e.g. 2h or 1d. SWO does not end it, run 'maintenance stop --expired' when it has passed",
+					},
+					&cli.StringFlag{
+						Name:  "reason",
+						Usage: "Why the entities are in maintenance",
+					},
+					autoApproveFlag(),
+				),
+			},
+			{
+				Name:   "stop",
+				Usage:  "Take entities out of maintenance mode",
+				Action: runMaintenanceStop,
+				Flags: append(maintenanceSelectorFlags(),
+					&cli.BoolFlag{
+						Name:  "expired",
+						Usage: "Stop every maintenance window whose duration has passed",
+					},
+					autoApproveFlag(),
+				),
+			},
+			{
+				Name:   "schedule",
+				Usage:  "Schedule a maintenance window, which is started and stopped by 'maintenance run'",
+				Action: runMaintenanceSchedule,
+				Flags: append(maintenanceSelectorFlags(),
+					&cli.StringFlag{
+						Name:     "at",
+						Usage:    "When the maintenance starts, e.g. 'tonight 23:00' or '2024-05-13 13:00:00 UTC' (required)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "duration",
+						Usage:    "How long the maintenance lasts, e.g. 1h (required)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "reason",
+						Usage: "Why the entities are in maintenance",
+					},
+					scheduleFileFlag(),
+				),
+			},
+			{
+				Name:   "run",
+				Usage:  "Start and stop scheduled maintenance windows until interrupted",
+				Action: runMaintenanceRun,
+				Flags: []cli.Flag{
+					scheduleFileFlag(),
+					&cli.DurationFlag{
+						Name:  "interval",
+						Usage: "How often the schedule is checked",
+						Value: DefaultSchedulerInterval,
+					},
+					&cli.BoolFlag{
+						Name:  "once",
+						Usage: "Process the schedule a single time and exit, e.g. when run from cron",
+					},
+				},
+			},
+			{
+				Name:   "list",
+				Usage:  "List entities currently in maintenance, flagging windows whose duration has passed",
+				Action: runMaintenanceList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "type",
+						Aliases: []string{"t"},
+						Usage:   "Only list entities of this type",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+		},
+	}
+}
+
+func scheduleFileFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "schedule-file",
+		Usage: "Path to the maintenance schedule file",
+		Value: DefaultScheduleFile,
+	}
+}
+
+func newMaintenanceOptions(ctx *cli.Context) *Options {
+	opts := NewOptions()
+	opts.IDs = ctx.StringSlice("id")
+	opts.Type = ctx.String("type")
+	opts.Name = ctx.String("name")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+	return opts
+}
+
+func runMaintenanceStart(ctx *cli.Context) error {
+	opts := newMaintenanceOptions(ctx)
+	opts.Reason = ctx.String("reason")
+	opts.AutoApprove = ctx.Bool("auto-approve")
+	if duration := ctx.String("duration"); duration != "" {
+		d, err := shared.ParseDuration(duration)
+		if err != nil {
+			return err
+		}
+		opts.Duration = d
+	}
+
+	if err := opts.ValidateForMaintenance(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.StartMaintenance(context.Background())
+}
+
+func runMaintenanceStop(ctx *cli.Context) error {
+	opts := newMaintenanceOptions(ctx)
+	opts.Expired = ctx.Bool("expired")
+	opts.AutoApprove = ctx.Bool("auto-approve")
+
+	if err := opts.ValidateForMaintenance(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.StopMaintenance(context.Background())
+}
+
+func runMaintenanceList(ctx *cli.Context) error {
+	opts := newMaintenanceOptions(ctx)
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.ListMaintenance(context.Background())
+}
+
+func runMaintenanceSchedule(ctx *cli.Context) error {
+	opts := newMaintenanceOptions(ctx)
+	opts.Reason = ctx.String("reason")
+
+	at, err := shared.ParseTime(ctx.String("at"), time.Now())
+	if err != nil {
+		return err
+	}
+	opts.At = at
+
+	d, err := shared.ParseDuration(ctx.String("duration"))
+	if err != nil {
+		return err
+	}
+	opts.Duration = d
+
+	opts.ScheduleFile, err = shared.ExpandHome(ctx.String("schedule-file"))
+	if err != nil {
+		return err
+	}
+
+	if err := opts.ValidateForSchedule(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.ScheduleMaintenance(context.Background())
+}
+
+func runMaintenanceRun(ctx *cli.Context) error {
+	opts := newMaintenanceOptions(ctx)
+	opts.Interval = ctx.Duration("interval")
+	opts.Once = ctx.Bool("once")
+
+	var err error
+	opts.ScheduleFile, err = shared.ExpandHome(ctx.String("schedule-file"))
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return client.RunScheduler(runCtx)
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/urfave/cli/v2"
+)
+
+func runRelated(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.ID = ctx.String("id")
+	opts.Depth = ctx.Int("depth")
+	opts.Format = ctx.String("format")
+	opts.JSON = ctx.Bool("json")
+	if opts.JSON {
+		opts.Format = FormatJSON
+	}
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForRelated(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Related(context.Background())
+}
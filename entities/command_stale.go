@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/urfave/cli/v2"
+)
+
+func runStale(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Type = ctx.String("type")
+	opts.Name = ctx.String("name")
+	opts.Delete = ctx.Bool("delete")
+	opts.IncludeNeverSeen = ctx.Bool("include-never-seen")
+	opts.Concurrency = ctx.Int("concurrency")
+	opts.AutoApprove = ctx.Bool("auto-approve")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	olderThan, err := shared.ParseDuration(ctx.String("older-than"))
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %w", err)
+	}
+	opts.StaleAfter = olderThan
+
+	if err := opts.ParseTags(ctx.StringSlice("tag")); err != nil {
+		return err
+	}
+
+	if err := opts.ValidateForStale(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Stale(context.Background())
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/urfave/cli/v2"
+)
+
+func runSummary(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Type = ctx.String("type")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	staleAfter, err := shared.ParseDuration(ctx.String("stale-after"))
+	if err != nil {
+		return fmt.Errorf("invalid --stale-after: %w", err)
+	}
+	opts.StaleAfter = staleAfter
+
+	if err := setCacheOptions(ctx, opts); err != nil {
+		return err
+	}
+
+	if err := opts.ValidateForSummary(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.Summary(context.Background())
+}
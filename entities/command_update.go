@@ -10,17 +10,39 @@
 func runUpdate(ctx *cli.Context) error {
 	opts := NewOptions()
 	opts.ID = ctx.String("id")
+	opts.Name = ctx.String("name")
+	if opts.Name == "" {
+		opts.Name = ctx.Args().First()
+	}
+	opts.Type = ctx.String("type")
 	opts.JSON = ctx.Bool("json")
+	opts.RemoveTags = ctx.StringSlice("remove-tag")
+	opts.ReplaceTags = ctx.Bool("replace-tags")
+	opts.IfUpdatedBefore = ctx.String("if-updated-before")
 	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
 	opts.Token = ctx.String(config.TokenContextKey)
 	opts.APIURL = ctx.String(config.APIURLContextKey)
 
+	if err := setCacheOptions(ctx, opts); err != nil {
+		return err
+	}
+	if opts.Offline {
+		return errOffline
+	}
+
 	// Parse tags
 	tagStrings := ctx.StringSlice("tag")
 	if err := opts.ParseTags(tagStrings); err != nil {
 		return err
 	}
 
+	if tagFile := ctx.String("tag-file"); tagFile != "" {
+		if err := opts.LoadTagFile(tagFile); err != nil {
+			return err
+		}
+	}
+
 	if err := opts.ValidateForUpdate(); err != nil {
 		return err
 	}
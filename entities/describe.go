@@ -0,0 +1,273 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultSampleSize is how many entities are sampled to infer attributes and tag keys
+	DefaultSampleSize = 100
+
+	sourceSchema  = "schema"
+	sourceSampled = "sampled"
+
+	maxExamples      = 3
+	maxExampleLength = 40
+)
+
+var errSampleComplete = errors.New("sample complete")
+
+type attributeSchema struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+type typeSchemaResponse struct {
+	Type       string            `json:"type"`
+	Attributes []attributeSchema `json:"attributes"`
+}
+
+// FieldDescription describes an attribute or tag key observed for an entity type
+type FieldDescription struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Source      string   `json:"source"`
+	Description string   `json:"description,omitempty"`
+	Seen        int      `json:"seen"`
+	Examples    []string `json:"examples,omitempty"`
+}
+
+// TypeDescription is the schema of an entity type, as known by the metadata API
+// and as inferred from a sample of its entities
+type TypeDescription struct {
+	Type       string             `json:"type"`
+	Sampled    int                `json:"sampled"`
+	Attributes []FieldDescription `json:"attributes"`
+	Tags       []FieldDescription `json:"tags"`
+}
+
+func (c *Client) prepareTypeSchemaRequest(ctx context.Context, entityType string) (*http.Request, error) {
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, nil, "", "v1/metadata/entities/types", entityType)
+}
+
+// fetchTypeSchema retrieves the known attributes of an entity type from the metadata API
+func (c *Client) fetchTypeSchema(ctx context.Context, entityType string) ([]attributeSchema, error) {
+	request, err := c.prepareTypeSchemaRequest(ctx, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	var response typeSchemaResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return response.Attributes, nil
+}
+
+// sampleEntities collects up to size entities of the given type
+func (c *Client) sampleEntities(ctx context.Context, entityType string, size int) ([]Entity, error) {
+	var sample []Entity
+	err := c.listEntities(ctx, entityType, "", func(entities []Entity) error {
+		for _, entity := range entities {
+			if len(sample) == size {
+				return errSampleComplete
+			}
+			sample = append(sample, entity)
+		}
+		if len(sample) == size {
+			return errSampleComplete
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errSampleComplete) {
+		return nil, err
+	}
+
+	return sample, nil
+}
+
+// valueType returns the JSON type of an attribute value
+func valueType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func formatExample(value interface{}) string {
+	var example string
+	switch v := value.(type) {
+	case string:
+		example = v
+	case []interface{}, map[string]interface{}:
+		jsonData, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		example = string(jsonData)
+	default:
+		example = fmt.Sprint(v)
+	}
+
+	runes := []rune(example)
+	if len(runes) > maxExampleLength {
+		return string(runes[:maxExampleLength-3]) + "..."
+	}
+	return example
+}
+
+// fieldStats accumulates what was observed for a single attribute or tag key
+type fieldStats struct {
+	seen     int
+	types    map[string]bool
+	examples []string
+}
+
+func (s *fieldStats) observe(typ string, example string) {
+	s.seen++
+	s.types[typ] = true
+	if len(s.examples) == maxExamples {
+		return
+	}
+	for _, e := range s.examples {
+		if e == example {
+			return
+		}
+	}
+	s.examples = append(s.examples, example)
+}
+
+func observe(stats map[string]*fieldStats, key string, typ string, example string) {
+	s, ok := stats[key]
+	if !ok {
+		s = &fieldStats{types: make(map[string]bool)}
+		stats[key] = s
+	}
+	s.observe(typ, example)
+}
+
+func (s *fieldStats) typeName() string {
+	types := make([]string, 0, len(s.types))
+	for typ := range s.types {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return strings.Join(types, "|")
+}
+
+// describeType merges the known attribute schema with the attributes and tag keys
+// observed in the sample
+func describeType(entityType string, schema []attributeSchema, sample []Entity) *TypeDescription {
+	attributes := make(map[string]*fieldStats)
+	tags := make(map[string]*fieldStats)
+	for _, entity := range sample {
+		for key, value := range entity.Attributes {
+			observe(attributes, key, valueType(value), formatExample(value))
+		}
+		for key, value := range entity.Tags {
+			observe(tags, key, "string", formatExample(tagValue(value)))
+		}
+	}
+
+	description := &TypeDescription{Type: entityType, Sampled: len(sample), Attributes: []FieldDescription{}, Tags: []FieldDescription{}}
+
+	known := make(map[string]bool)
+	for _, attribute := range schema {
+		known[attribute.Name] = true
+		field := FieldDescription{Name: attribute.Name, Type: attribute.Type, Source: sourceSchema, Description: attribute.Description}
+		if s, ok := attributes[attribute.Name]; ok {
+			field.Seen = s.seen
+			field.Examples = s.examples
+		}
+		description.Attributes = append(description.Attributes, field)
+	}
+
+	for key, s := range attributes {
+		if !known[key] {
+			description.Attributes = append(description.Attributes, FieldDescription{Name: key, Type: s.typeName(), Source: sourceSampled, Seen: s.seen, Examples: s.examples})
+		}
+	}
+	for key, s := range tags {
+		description.Tags = append(description.Tags, FieldDescription{Name: key, Type: s.typeName(), Source: sourceSampled, Seen: s.seen, Examples: s.examples})
+	}
+
+	sort.Slice(description.Attributes, func(i, j int) bool { return description.Attributes[i].Name < description.Attributes[j].Name })
+	sort.Slice(description.Tags, func(i, j int) bool { return description.Tags[i].Name < description.Tags[j].Name })
+
+	return description
+}
+
+func (c *Client) printTypeDescription(description *TypeDescription) error {
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(description)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Type: %s (%d entities sampled)\n", description.Type, description.Sampled)
+
+	w := tabwriter.NewWriter(c.output, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\nATTRIBUTE\tTYPE\tSOURCE\tSEEN\tEXAMPLES")
+	for _, field := range description.Attributes {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\n", field.Name, field.Type, field.Source, field.Seen, description.Sampled, strings.Join(field.Examples, ", "))
+	}
+	_, _ = fmt.Fprintln(w, "\nTAG\tTYPE\tSOURCE\tSEEN\tEXAMPLES")
+	for _, field := range description.Tags {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\n", field.Name, field.Type, field.Source, field.Seen, description.Sampled, strings.Join(field.Examples, ", "))
+	}
+
+	return w.Flush()
+}
+
+// DescribeType shows the attributes and tag keys of an entity type. Attributes
+// missing from the metadata API are inferred by sampling entities of the type.
+func (c *Client) DescribeType(ctx context.Context) error {
+	schema, err := c.fetchTypeSchema(ctx, c.opts.Type)
+	if err != nil {
+		// Not every type has a published schema, sampling still describes it
+		slog.Warn("Failed to get the schema of the entity type, inferring it from entities only", "type", c.opts.Type, "error", err)
+	}
+
+	sample, err := c.sampleEntities(ctx, c.opts.Type, c.opts.Sample)
+	if err != nil {
+		return fmt.Errorf("failed to list entities of type %s: %w", c.opts.Type, err)
+	}
+
+	return c.printTypeDescription(describeType(c.opts.Type, schema, sample))
+}
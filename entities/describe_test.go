@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeType(t *testing.T) {
+	schema := []attributeSchema{
+		{Name: "os", Type: "string", Description: "Operating system"},
+		{Name: "cpuCount", Type: "integer"},
+	}
+	sample := []Entity{
+		{ID: "e-1", Type: "Host", Attributes: map[string]interface{}{"os": "linux", "cpuCount": float64(4), "ips": []interface{}{"10.0.0.1"}}, Tags: map[string]*string{"env": stringPtr("prod")}},
+		{ID: "e-2", Type: "Host", Attributes: map[string]interface{}{"os": "linux", "uptime": "3d"}, Tags: map[string]*string{"env": stringPtr("dev"), "team": nil}},
+		{ID: "e-3", Type: "Host", Attributes: map[string]interface{}{"os": "windows", "uptime": float64(7)}},
+	}
+
+	description := describeType("Host", schema, sample)
+	require.Equal(t, 3, description.Sampled)
+	require.Equal(t, []FieldDescription{
+		{Name: "cpuCount", Type: "integer", Source: sourceSchema, Seen: 1, Examples: []string{"4"}},
+		{Name: "ips", Type: "array", Source: sourceSampled, Seen: 1, Examples: []string{`["10.0.0.1"]`}},
+		{Name: "os", Type: "string", Source: sourceSchema, Description: "Operating system", Seen: 3, Examples: []string{"linux", "windows"}},
+		{Name: "uptime", Type: "number|string", Source: sourceSampled, Seen: 2, Examples: []string{"3d", "7"}},
+	}, description.Attributes)
+	require.Equal(t, []FieldDescription{
+		{Name: "env", Type: "string", Source: sourceSampled, Seen: 2, Examples: []string{"prod", "dev"}},
+		{Name: "team", Type: "string", Source: sourceSampled, Seen: 1, Examples: []string{""}},
+	}, description.Tags)
+}
+
+func TestFormatExample(t *testing.T) {
+	require.Equal(t, "true", formatExample(true))
+	require.Equal(t, "1.5", formatExample(1.5))
+	require.Equal(t, `{"a":1}`, formatExample(map[string]interface{}{"a": 1}))
+
+	long := formatExample("0123456789012345678901234567890123456789012345")
+	require.Len(t, long, maxExampleLength)
+	require.Equal(t, "...", long[len(long)-3:])
+
+	// Multi-byte characters are never cut in half
+	long = formatExample(strings.Repeat("ü", 50))
+	require.True(t, utf8.ValidString(long))
+	require.Equal(t, strings.Repeat("ü", maxExampleLength-3)+"...", long)
+}
+
+func TestDescribeTypeSamplesEntities(t *testing.T) {
+	var entities []Entity
+	for i := 0; i < 5; i++ {
+		entities = append(entities, Entity{ID: fmt.Sprintf("e-%d", i), Type: "Host", Attributes: map[string]interface{}{"index": float64(i)}})
+	}
+	server, store := newEntityServer(t, entities)
+	defer server.Close()
+	store.pageSize = 2
+
+	opts := NewOptions()
+	opts.Type = "Host"
+	opts.Sample = 3
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	// The entity store has no schema endpoint, so attributes are only sampled
+	require.NoError(t, client.DescribeType(context.Background()))
+
+	var description TypeDescription
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &description))
+	require.Equal(t, 3, description.Sampled)
+	require.Equal(t, []FieldDescription{
+		{Name: "index", Type: "number", Source: sourceSampled, Seen: 3, Examples: []string{"0", "1", "2"}},
+	}, description.Attributes)
+}
@@ -0,0 +1,273 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+)
+
+const (
+	fieldDisplayName   = "displayName"
+	fieldInMaintenance = "inMaintenance"
+	fieldTag           = "tag"
+	fieldAttribute     = "attribute"
+)
+
+var (
+	// ErrDriftDetected indicates that the compared inventories differ, returned only with --exit-code
+	ErrDriftDetected = errors.New("entity inventories differ")
+)
+
+// FieldChange describes a changed property of an entity present in both inventories
+type FieldChange struct {
+	Field    string      `json:"field"`
+	Action   string      `json:"action"`
+	Key      string      `json:"key,omitempty"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
+
+// EntityChange lists the changes of a single entity present in both inventories
+type EntityChange struct {
+	ID      string        `json:"id"`
+	Type    string        `json:"type"`
+	Name    string        `json:"name,omitempty"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// InventoryDiff is the difference between two entity inventories
+type InventoryDiff struct {
+	Added   []Entity       `json:"added"`
+	Removed []Entity       `json:"removed"`
+	Changed []EntityChange `json:"changed"`
+}
+
+// Empty reports whether the inventories are the same
+func (d *InventoryDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// entityKey returns the key used to pair entities of two inventories
+func entityKey(entity *Entity, match string) string {
+	if match == MatchByName {
+		return entity.Type + "/" + entity.Name
+	}
+	return entity.ID
+}
+
+func indexEntities(entities []Entity, match string) (map[string]*Entity, []string) {
+	index := make(map[string]*Entity, len(entities))
+	keys := make([]string, 0, len(entities))
+	for i := range entities {
+		key := entityKey(&entities[i], match)
+		if _, ok := index[key]; ok {
+			slog.Warn("Duplicate entity key, keeping the first entity", "key", key, "id", entities[i].ID)
+			continue
+		}
+		index[key] = &entities[i]
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return index, keys
+}
+
+// diffInventories compares two entity inventories, pairing entities by ID or by type and name
+func diffInventories(before, after []Entity, match string) *InventoryDiff {
+	beforeIndex, beforeKeys := indexEntities(before, match)
+	afterIndex, afterKeys := indexEntities(after, match)
+
+	diff := &InventoryDiff{Added: []Entity{}, Removed: []Entity{}, Changed: []EntityChange{}}
+	for _, key := range beforeKeys {
+		oldEntity := beforeIndex[key]
+		newEntity, ok := afterIndex[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, *oldEntity)
+			continue
+		}
+
+		if changes := diffEntity(oldEntity, newEntity); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, EntityChange{ID: newEntity.ID, Type: newEntity.Type, Name: newEntity.Name, Changes: changes})
+		}
+	}
+
+	for _, key := range afterKeys {
+		if _, ok := beforeIndex[key]; !ok {
+			diff.Added = append(diff.Added, *afterIndex[key])
+		}
+	}
+
+	return diff
+}
+
+// diffEntity compares display name, maintenance state, tags and attributes of two entities
+func diffEntity(before, after *Entity) []FieldChange {
+	var changes []FieldChange
+
+	if before.DisplayName != after.DisplayName {
+		changes = append(changes, FieldChange{Field: fieldDisplayName, Action: tagChangeUpdate, OldValue: before.DisplayName, NewValue: after.DisplayName})
+	}
+	if before.InMaintenance != after.InMaintenance {
+		changes = append(changes, FieldChange{Field: fieldInMaintenance, Action: tagChangeUpdate, OldValue: before.InMaintenance, NewValue: after.InMaintenance})
+	}
+
+	for _, change := range diffTags(before.Tags, after.Tags) {
+		fieldChange := FieldChange{Field: fieldTag, Action: change.Action, Key: change.Key}
+		if change.Action != tagChangeAdd {
+			fieldChange.OldValue = change.OldValue
+		}
+		if change.Action != tagChangeRemove {
+			fieldChange.NewValue = change.NewValue
+		}
+		changes = append(changes, fieldChange)
+	}
+
+	keys := make(map[string]bool)
+	for key := range before.Attributes {
+		keys[key] = true
+	}
+	for key := range after.Attributes {
+		keys[key] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		oldValue, hadValue := before.Attributes[key]
+		newValue, hasValue := after.Attributes[key]
+		switch {
+		case !hadValue:
+			changes = append(changes, FieldChange{Field: fieldAttribute, Action: tagChangeAdd, Key: key, NewValue: newValue})
+		case !hasValue:
+			changes = append(changes, FieldChange{Field: fieldAttribute, Action: tagChangeRemove, Key: key, OldValue: oldValue})
+		case !reflect.DeepEqual(oldValue, newValue):
+			changes = append(changes, FieldChange{Field: fieldAttribute, Action: tagChangeUpdate, Key: key, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	return changes
+}
+
+func describeEntity(entity *Entity) string {
+	if entity.Name != "" {
+		return fmt.Sprintf("%s %s (%s)", entity.Type, entity.Name, entity.ID)
+	}
+	return fmt.Sprintf("%s (%s)", entity.Type, entity.ID)
+}
+
+func (c *Client) printInventoryDiff(diff *InventoryDiff) error {
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(diff)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	for i := range diff.Added {
+		_, _ = fmt.Fprintf(c.output, "+ %s\n", describeEntity(&diff.Added[i]))
+	}
+	for i := range diff.Removed {
+		_, _ = fmt.Fprintf(c.output, "- %s\n", describeEntity(&diff.Removed[i]))
+	}
+	for _, changed := range diff.Changed {
+		_, _ = fmt.Fprintf(c.output, "~ %s\n", describeEntity(&Entity{ID: changed.ID, Type: changed.Type, Name: changed.Name}))
+		for _, change := range changed.Changes {
+			switch {
+			case change.Key == "":
+				_, _ = fmt.Fprintf(c.output, "    %s: %v -> %v\n", change.Field, change.OldValue, change.NewValue)
+			case change.Action == tagChangeAdd:
+				_, _ = fmt.Fprintf(c.output, "    %s + %s=%v\n", change.Field, change.Key, change.NewValue)
+			case change.Action == tagChangeRemove:
+				_, _ = fmt.Fprintf(c.output, "    %s - %s=%v\n", change.Field, change.Key, change.OldValue)
+			default:
+				_, _ = fmt.Fprintf(c.output, "    %s ~ %s=%v -> %v\n", change.Field, change.Key, change.OldValue, change.NewValue)
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(c.output, "%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	return nil
+}
+
+// liveInventory lists the live entities of the given type, or of every type present in the snapshot
+func (c *Client) liveInventory(ctx context.Context, snapshot []Entity) ([]Entity, error) {
+	types := []string{c.opts.Type}
+	if c.opts.Type == "" {
+		seen := make(map[string]bool)
+		types = nil
+		for _, entity := range snapshot {
+			if !seen[entity.Type] {
+				seen[entity.Type] = true
+				types = append(types, entity.Type)
+			}
+		}
+		sort.Strings(types)
+	}
+
+	var live []Entity
+	for _, entityType := range types {
+		entities, err := c.fetchEntities(ctx, entityType, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list entities of type %s: %w", entityType, err)
+		}
+		live = append(live, entities...)
+	}
+
+	return live, nil
+}
+
+func filterByType(entities []Entity, entityType string) []Entity {
+	if entityType == "" {
+		return entities
+	}
+
+	var result []Entity
+	for _, entity := range entities {
+		if entity.Type == entityType {
+			result = append(result, entity)
+		}
+	}
+	return result
+}
+
+// Diff compares two snapshots, or a snapshot with the live inventory when only one is given
+func (c *Client) Diff(ctx context.Context) error {
+	before, err := readSnapshotFile(c.opts.Snapshots[0])
+	if err != nil {
+		return err
+	}
+	before = filterByType(before, c.opts.Type)
+
+	var after []Entity
+	if len(c.opts.Snapshots) > 1 {
+		after, err = readSnapshotFile(c.opts.Snapshots[1])
+		if err != nil {
+			return err
+		}
+		after = filterByType(after, c.opts.Type)
+	} else {
+		after, err = c.liveInventory(ctx, before)
+		if err != nil {
+			return err
+		}
+	}
+
+	diff := diffInventories(before, after, c.opts.Match)
+	if err := c.printInventoryDiff(diff); err != nil {
+		return err
+	}
+
+	if c.opts.ExitCode && !diff.Empty() {
+		return ErrDriftDetected
+	}
+
+	return nil
+}
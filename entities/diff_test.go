@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	inventoryBefore = []Entity{
+		{ID: "e-1", Type: "Host", Name: "web-01", DisplayName: "Web 1", Tags: map[string]*string{"env": stringPtr("staging")}, Attributes: map[string]interface{}{"os": "linux", "cpus": float64(2)}},
+		{ID: "e-2", Type: "Host", Name: "web-02", Tags: map[string]*string{"env": stringPtr("production")}},
+		{ID: "e-3", Type: "Service", Name: "checkout"},
+	}
+	inventoryAfter = []Entity{
+		{ID: "e-1", Type: "Host", Name: "web-01", DisplayName: "Web One", InMaintenance: true, Tags: map[string]*string{"env": stringPtr("production"), "team": stringPtr("backend")}, Attributes: map[string]interface{}{"cpus": float64(4), "arch": "arm64"}},
+		{ID: "e-3", Type: "Service", Name: "checkout"},
+		{ID: "e-4", Type: "Host", Name: "web-03"},
+	}
+)
+
+func TestDiffInventoriesByID(t *testing.T) {
+	diff := diffInventories(inventoryBefore, inventoryAfter, MatchByID)
+
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, "e-4", diff.Added[0].ID)
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, "e-2", diff.Removed[0].ID)
+	require.Len(t, diff.Changed, 1)
+	require.Equal(t, "e-1", diff.Changed[0].ID)
+	require.Equal(t, []FieldChange{
+		{Field: fieldDisplayName, Action: tagChangeUpdate, OldValue: "Web 1", NewValue: "Web One"},
+		{Field: fieldInMaintenance, Action: tagChangeUpdate, OldValue: false, NewValue: true},
+		{Field: fieldTag, Action: tagChangeUpdate, Key: "env", OldValue: "staging", NewValue: "production"},
+		{Field: fieldTag, Action: tagChangeAdd, Key: "team", NewValue: "backend"},
+		{Field: fieldAttribute, Action: tagChangeAdd, Key: "arch", NewValue: "arm64"},
+		{Field: fieldAttribute, Action: tagChangeUpdate, Key: "cpus", OldValue: float64(2), NewValue: float64(4)},
+		{Field: fieldAttribute, Action: tagChangeRemove, Key: "os", OldValue: "linux"},
+	}, diff.Changed[0].Changes)
+	require.False(t, diff.Empty())
+
+	require.True(t, diffInventories(inventoryBefore, inventoryBefore, MatchByID).Empty())
+}
+
+func TestDiffInventoriesByName(t *testing.T) {
+	otherOrg := []Entity{
+		{ID: "x-1", Type: "Host", Name: "web-01", DisplayName: "Web 1", Tags: map[string]*string{"env": stringPtr("staging")}, Attributes: map[string]interface{}{"os": "linux", "cpus": float64(2)}},
+		{ID: "x-2", Type: "Host", Name: "web-02", Tags: map[string]*string{"env": stringPtr("production")}},
+		{ID: "x-3", Type: "Service", Name: "checkout"},
+	}
+
+	require.True(t, diffInventories(inventoryBefore, otherOrg, MatchByName).Empty())
+	require.False(t, diffInventories(inventoryBefore, otherOrg, MatchByID).Empty())
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	encode := func(entities []Entity) []string {
+		var lines []string
+		for _, entity := range entities {
+			data, err := json.Marshal(entity)
+			require.NoError(t, err)
+			lines = append(lines, string(data))
+		}
+		return lines
+	}
+	a := writeSnapshot(t, encode(inventoryBefore)...)
+	b := writeSnapshot(t, encode(inventoryAfter)...)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: "http://127.0.0.1:0"}, Snapshots: []string{a, b}, Match: MatchByID})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Diff(context.Background()))
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "+ Host web-03 (e-4)")
+	require.Contains(t, output, "- Host web-02 (e-2)")
+	require.Contains(t, output, "~ Host web-01 (e-1)")
+	require.Contains(t, output, "    displayName: Web 1 -> Web One")
+	require.Contains(t, output, "    inMaintenance: false -> true")
+	require.Contains(t, output, "    tag ~ env=staging -> production")
+	require.Contains(t, output, "    attribute - os=linux")
+	require.Contains(t, output, "1 added, 1 removed, 1 changed")
+
+	client, err = NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: "http://127.0.0.1:0"}, Snapshots: []string{a, b}, Match: MatchByID, Type: "Service", ExitCode: true, JSON: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Diff(context.Background()))
+
+	var diff InventoryDiff
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &diff))
+	require.True(t, diff.Empty())
+}
+
+func TestDiffLive(t *testing.T) {
+	server, _ := newEntityServer(t, inventoryAfter)
+
+	data, err := json.Marshal(inventoryBefore[1])
+	require.NoError(t, err)
+	snapshot := writeSnapshot(t, string(data))
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Snapshots: []string{snapshot}, Match: MatchByID, ExitCode: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	err = client.Diff(context.Background())
+	require.ErrorIs(t, err, ErrDriftDetected)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "- Host web-02 (e-2)")
+	require.Contains(t, output, "+ Host web-01 (e-1)")
+	require.Contains(t, output, "+ Host web-03 (e-4)")
+	require.NotContains(t, output, "checkout")
+}
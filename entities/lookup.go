@@ -0,0 +1,184 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+var errPartialMatch = errors.New("name only partially matches")
+
+// matchesName reports whether the name or display name of an entity contains the
+// searched name, ignoring case
+func matchesName(entity *Entity, name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(strings.ToLower(entity.Name), name) ||
+		strings.Contains(strings.ToLower(entity.DisplayName), name)
+}
+
+// findEntities searches entities by name within the given type, or within all
+// types. A fresh entity cache is searched instead of the API. Otherwise the API
+// name filter is tried first; when it finds nothing and a type is given, the
+// entities of the type are searched locally for partial matches.
+func (c *Client) findEntities(ctx context.Context, entityType string, name string) ([]Entity, error) {
+	cache, err := c.freshCache()
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		var result []Entity
+		for _, entity := range cache.Entities {
+			if (entityType == "" || entity.Type == entityType) && matchesName(&entity, name) {
+				result = append(result, entity)
+			}
+		}
+		sortEntities(result)
+		return result, nil
+	}
+
+	types := []string{entityType}
+	if entityType == "" {
+		types, err = c.fetchTypes(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	var result []Entity
+	collect := func(entities []Entity) error {
+		for _, entity := range entities {
+			if !seen[entity.ID] && matchesName(&entity, name) {
+				seen[entity.ID] = true
+				result = append(result, entity)
+			}
+		}
+		return nil
+	}
+
+	for _, t := range types {
+		if err := c.listEntities(ctx, t, name, collect); err != nil {
+			return nil, fmt.Errorf("failed to list entities of type %s: %w", t, err)
+		}
+	}
+
+	if len(result) == 0 && entityType != "" {
+		if err := c.listEntities(ctx, entityType, "", collect); err != nil {
+			return nil, fmt.Errorf("failed to list entities of type %s: %w", entityType, err)
+		}
+	}
+
+	sortEntities(result)
+	return result, nil
+}
+
+func sortEntities(entities []Entity) {
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Type != entities[j].Type {
+			return entities[i].Type < entities[j].Type
+		}
+		if entities[i].Name != entities[j].Name {
+			return entities[i].Name < entities[j].Name
+		}
+		return entities[i].ID < entities[j].ID
+	})
+}
+
+// bestMatches narrows the candidates to the exact name matches, if there are any
+func bestMatches(candidates []Entity, name string) []Entity {
+	var exact, folded []Entity
+	for _, entity := range candidates {
+		switch {
+		case entity.Name == name:
+			exact = append(exact, entity)
+		case strings.EqualFold(entity.Name, name):
+			folded = append(folded, entity)
+		}
+	}
+
+	switch {
+	case len(exact) > 0:
+		return exact
+	case len(folded) > 0:
+		return folded
+	default:
+		return candidates
+	}
+}
+
+// resolveName returns the ID of the entity selected by --name. When more than one
+// entity matches, the user picks one on a terminal, otherwise the candidates are
+// listed in the error. With exact, as for updates, a single entity whose name only
+// contains the searched name has to be confirmed on a terminal. Prompts go to
+// stderr, so they do not mix with --json output.
+func (c *Client) resolveName(ctx context.Context, exact bool) (string, error) {
+	candidates, err := c.findEntities(ctx, c.opts.Type, c.opts.Name)
+	if err != nil {
+		return "", err
+	}
+
+	candidates = bestMatches(candidates, c.opts.Name)
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", errNoMatchingEntity, c.opts.Name)
+	case 1:
+		if exact && candidates[0].Name != c.opts.Name {
+			return c.confirmPartialMatch(&candidates[0])
+		}
+		return candidates[0].ID, nil
+	}
+
+	descriptions := make([]string, len(candidates))
+	for i := range candidates {
+		descriptions[i] = describeEntity(&candidates[i])
+	}
+
+	if !shared.IsTerminal(c.input) {
+		return "", fmt.Errorf("%w %q, use --id or --type to select one of:\n  %s",
+			errAmbiguousEntity, c.opts.Name, strings.Join(descriptions, "\n  "))
+	}
+
+	choice, err := shared.Choose(c.input, c.prompts, fmt.Sprintf("More than one entity matches %q:", c.opts.Name), descriptions)
+	if err != nil {
+		return "", err
+	}
+	return candidates[choice].ID, nil
+}
+
+// confirmPartialMatch asks before acting on an entity whose name is not the searched name
+func (c *Client) confirmPartialMatch(entity *Entity) (string, error) {
+	description := describeEntity(entity)
+	if !shared.IsTerminal(c.input) {
+		return "", fmt.Errorf("%w: %q matches %s, use the exact name or --id",
+			errPartialMatch, c.opts.Name, description)
+	}
+
+	approved, err := shared.Confirm(c.input, c.prompts,
+		fmt.Sprintf("%q only partially matches %s. Do you want to use it?", c.opts.Name, description))
+	if err != nil {
+		return "", err
+	}
+	if !approved {
+		return "", fmt.Errorf("%w: %q matches %s", errPartialMatch, c.opts.Name, description)
+	}
+	return entity.ID, nil
+}
+
+// resolveID looks up the entity ID by name unless it was given with --id. With
+// exact, a partial name match has to be confirmed.
+func (c *Client) resolveID(ctx context.Context, exact bool) error {
+	if strings.TrimSpace(c.opts.ID) != "" {
+		return nil
+	}
+
+	id, err := c.resolveName(ctx, exact)
+	if err != nil {
+		return err
+	}
+	c.opts.ID = id
+	return nil
+}
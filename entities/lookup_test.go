@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var lookupEntities = []Entity{
+	{ID: "e-1", Type: "Host", Name: "web-01", Tags: map[string]*string{}},
+	{ID: "e-2", Type: "Host", Name: "web-02", Tags: map[string]*string{}},
+	{ID: "e-3", Type: "Service", Name: "web-01", Tags: map[string]*string{}},
+	{ID: "e-4", Type: "Host", Name: "db-01", DisplayName: "Primary Database", Tags: map[string]*string{}},
+}
+
+func TestResolveName(t *testing.T) {
+	server, _ := newEntityServer(t, lookupEntities)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		entityType string
+		search     string
+		expectedID string
+		expectErr  error
+	}{
+		{"exact name within type", "Host", "web-01", "e-1", nil},
+		{"partial name within type", "Host", "web-02", "e-2", nil},
+		{"case-insensitive partial name", "Host", "WEB-0", "", errAmbiguousEntity},
+		{"display name", "Host", "database", "e-4", nil},
+		{"exact name in several types", "", "web-01", "", errAmbiguousEntity},
+		{"unique name across types", "", "db-01", "e-4", nil},
+		{"no match", "Host", "cache", "", errNoMatchingEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			opts.Type = tt.entityType
+			opts.Name = tt.search
+			opts.Token = "test-token"
+			opts.APIURL = server.URL
+			client, err := NewClient(opts)
+			require.NoError(t, err)
+			client.output = testutil.TempFile(t)
+			client.input = testutil.TempFile(t)
+			client.prompts = testutil.TempFile(t)
+
+			id, err := client.resolveName(context.Background(), false)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedID, id)
+		})
+	}
+}
+
+func TestResolveNameListsCandidates(t *testing.T) {
+	server, _ := newEntityServer(t, lookupEntities)
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.Name = "web-01"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	_, err = client.resolveName(context.Background(), false)
+	require.ErrorIs(t, err, errAmbiguousEntity)
+	require.True(t, strings.Contains(err.Error(), "Host web-01 (e-1)"), err.Error())
+	require.True(t, strings.Contains(err.Error(), "Service web-01 (e-3)"), err.Error())
+}
+
+func TestUpdateEntityByName(t *testing.T) {
+	server, store := newEntityServer(t, lookupEntities)
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.Type = "Host"
+	opts.Name = "db-01"
+	opts.Tags = map[string]string{"env": "prod"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.UpdateEntity(context.Background()))
+	require.Equal(t, "e-4", opts.ID)
+	require.Equal(t, "prod", *store.get("e-4").Tags["env"])
+}
+
+func TestUpdateEntityByPartialName(t *testing.T) {
+	server, store := newEntityServer(t, lookupEntities)
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.Type = "Host"
+	opts.Name = "database"
+	opts.Tags = map[string]string{"env": "prod"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	// Without a terminal to confirm on, only an exact name is used for updates
+	err = client.UpdateEntity(context.Background())
+	require.ErrorIs(t, err, errPartialMatch)
+	require.Contains(t, err.Error(), "Host db-01 (e-4)")
+	require.Zero(t, store.putCount())
+}
@@ -0,0 +1,319 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// MaintenanceUntilTag is the tag recording when a maintenance window should end
+	MaintenanceUntilTag = "maintenance-until"
+	// MaintenanceReasonTag is the tag recording why an entity is in maintenance
+	MaintenanceReasonTag = "maintenance-reason"
+)
+
+var errMaintenanceFailed = errors.New("failed to change maintenance of some entities")
+
+// maintenanceEntry is an entity in maintenance as shown by maintenance list
+type maintenanceEntry struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name,omitempty"`
+	Until   string `json:"until,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Overdue bool   `json:"overdue"`
+}
+
+type maintenanceResult struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	Name          string `json:"name,omitempty"`
+	InMaintenance bool   `json:"inMaintenance"`
+	Until         string `json:"until,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+}
+
+// maintenanceUntil returns the recorded end of the maintenance window of an entity
+func maintenanceUntil(entity *Entity) (time.Time, bool) {
+	value, ok := entity.Tags[MaintenanceUntilTag]
+	if !ok || value == nil {
+		return time.Time{}, false
+	}
+
+	until, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// selectEntities returns the entities with the given IDs, or all entities matched by type and name
+func (c *Client) selectEntities(ctx context.Context, ids []string, entityType string, name string) ([]Entity, error) {
+	if len(ids) == 0 {
+		return c.fetchEntities(ctx, entityType, name)
+	}
+
+	entities := make([]Entity, 0, len(ids))
+	for _, id := range ids {
+		entity, err := c.getEntity(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get entity %s: %w", id, err)
+		}
+		entities = append(entities, *entity)
+	}
+	return entities, nil
+}
+
+// entitiesInMaintenance lists the entities in maintenance of the given type, or of all types
+func (c *Client) entitiesInMaintenance(ctx context.Context, entityType string) ([]Entity, error) {
+	types := []string{entityType}
+	if entityType == "" {
+		var err error
+		types, err = c.fetchTypes(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result []Entity
+	for _, t := range types {
+		err := c.listEntities(ctx, t, c.opts.Name, func(entities []Entity) error {
+			for _, entity := range entities {
+				if entity.InMaintenance {
+					result = append(result, entity)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list entities of type %s: %w", t, err)
+		}
+	}
+
+	return result, nil
+}
+
+// setMaintenance turns maintenance of an entity on or off. The end of the window
+// and the reason are recorded as tags, so they show up in maintenance list and
+// expired windows can be stopped later. SWO does not enforce the end of the
+// window: it stays open until 'maintenance stop --expired' or a 'maintenance run'
+// scheduler ends it.
+func (c *Client) setMaintenance(ctx context.Context, entity Entity, enabled bool, until time.Time, reason string) maintenanceResult {
+	tagOpts := &Options{Tags: make(map[string]string)}
+	if enabled {
+		if !until.IsZero() {
+			tagOpts.Tags[MaintenanceUntilTag] = until.UTC().Format(time.RFC3339)
+		} else {
+			tagOpts.RemoveTags = append(tagOpts.RemoveTags, MaintenanceUntilTag)
+		}
+		if reason != "" {
+			tagOpts.Tags[MaintenanceReasonTag] = reason
+		}
+	} else {
+		tagOpts.RemoveTags = []string{MaintenanceUntilTag, MaintenanceReasonTag}
+	}
+
+	result := maintenanceResult{ID: entity.ID, Type: entity.Type, Name: entity.Name, InMaintenance: enabled, Reason: reason}
+	if !until.IsZero() {
+		result.Until = until.UTC().Format(time.RFC3339)
+	}
+
+	desired := entity
+	desired.InMaintenance = enabled
+	desired.Tags = tagOpts.computeTags(entity.Tags)
+	if entity.InMaintenance == enabled && len(diffTags(entity.Tags, desired.Tags)) == 0 {
+		result.Status = "unchanged"
+		return result
+	}
+
+	if err := c.putEntity(ctx, &desired); err != nil {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = "success"
+	if c.opts.DryRun {
+		result.Status = "dry-run"
+	}
+	return result
+}
+
+func (c *Client) printMaintenanceResult(result maintenanceResult) error {
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	action := "stopped"
+	if result.InMaintenance {
+		action = "started"
+	}
+
+	switch result.Status {
+	case "success":
+		_, _ = fmt.Fprintf(c.output, "Maintenance of entity %s %s", result.ID, action)
+		if result.Until != "" {
+			_, _ = fmt.Fprintf(c.output, " until %s", result.Until)
+		}
+		_, _ = fmt.Fprintln(c.output)
+	case "dry-run":
+		_, _ = fmt.Fprintf(c.output, "Dry run: maintenance of entity %s was not %s\n", result.ID, action)
+	case "unchanged":
+		_, _ = fmt.Fprintf(c.output, "Maintenance of entity %s already %s\n", result.ID, action)
+	default:
+		_, _ = fmt.Fprintf(c.output, "Failed to change maintenance of entity %s: %s\n", result.ID, result.Error)
+	}
+	return nil
+}
+
+func (c *Client) changeMaintenance(ctx context.Context, entities []Entity, enabled bool, until time.Time, reason string) error {
+	failed := 0
+	for _, entity := range entities {
+		result := c.setMaintenance(ctx, entity, enabled, until, reason)
+		if result.Status == "failed" {
+			failed++
+		}
+		if err := c.printMaintenanceResult(result); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errMaintenanceFailed, failed, len(entities))
+	}
+	return nil
+}
+
+// confirmMaintenance asks before changing the maintenance of more than one
+// entity, since a selector like --type alone may match a whole inventory
+func (c *Client) confirmMaintenance(entities []Entity, enabled bool) error {
+	if len(entities) <= 1 || c.opts.AutoApprove || c.opts.DryRun {
+		return nil
+	}
+
+	action := "stop"
+	if enabled {
+		action = "start"
+	}
+
+	return shared.Approve(c.input, c.prompts,
+		fmt.Sprintf("This will %s maintenance of %d entities. Do you want to continue?", action, len(entities)))
+}
+
+// StartMaintenance puts the selected entities into maintenance mode. The end of
+// the window is only recorded, it is not enforced by SWO.
+func (c *Client) StartMaintenance(ctx context.Context) error {
+	entities, err := c.selectEntities(ctx, c.opts.IDs, c.opts.Type, c.opts.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := c.confirmMaintenance(entities, true); err != nil {
+		return err
+	}
+
+	var until time.Time
+	if c.opts.Duration > 0 {
+		until = time.Now().Add(c.opts.Duration)
+	}
+
+	return c.changeMaintenance(ctx, entities, true, until, c.opts.Reason)
+}
+
+// StopMaintenance takes the selected entities out of maintenance mode. With
+// --expired it stops every window whose recorded end time has passed.
+func (c *Client) StopMaintenance(ctx context.Context) error {
+	var entities []Entity
+	var err error
+
+	if c.opts.Expired {
+		var inMaintenance []Entity
+		inMaintenance, err = c.entitiesInMaintenance(ctx, c.opts.Type)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, entity := range inMaintenance {
+			if until, ok := maintenanceUntil(&entity); ok && !until.After(now) {
+				entities = append(entities, entity)
+			}
+		}
+	} else {
+		entities, err = c.selectEntities(ctx, c.opts.IDs, c.opts.Type, c.opts.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.confirmMaintenance(entities, false); err != nil {
+		return err
+	}
+
+	return c.changeMaintenance(ctx, entities, false, time.Time{}, "")
+}
+
+func (c *Client) printMaintenanceEntries(entries []maintenanceEntry) error {
+	for _, entry := range entries {
+		if c.opts.JSON {
+			jsonData, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(c.output, string(jsonData))
+			continue
+		}
+
+		_, _ = fmt.Fprintf(c.output, "ID: %s, Type: %s", entry.ID, entry.Type)
+		if entry.Name != "" {
+			_, _ = fmt.Fprintf(c.output, ", Name: %s", entry.Name)
+		}
+		if entry.Until != "" {
+			_, _ = fmt.Fprintf(c.output, ", Until: %s", entry.Until)
+		}
+		if entry.Reason != "" {
+			_, _ = fmt.Fprintf(c.output, ", Reason: %s", entry.Reason)
+		}
+		if entry.Overdue {
+			_, _ = fmt.Fprint(c.output, ", Overdue: true")
+		}
+		_, _ = fmt.Fprintln(c.output)
+	}
+	return nil
+}
+
+// ListMaintenance displays all entities currently in maintenance. Windows whose
+// recorded end has passed are flagged as overdue, since SWO does not end them.
+func (c *Client) ListMaintenance(ctx context.Context) error {
+	entities, err := c.entitiesInMaintenance(ctx, c.opts.Type)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entries := make([]maintenanceEntry, len(entities))
+	for i, entity := range entities {
+		entries[i] = maintenanceEntry{ID: entity.ID, Type: entity.Type, Name: entity.Name}
+		if until, ok := maintenanceUntil(&entity); ok {
+			entries[i].Until = until.UTC().Format(time.RFC3339)
+			entries[i].Overdue = !until.After(now)
+		}
+		if reason := entity.Tags[MaintenanceReasonTag]; reason != nil {
+			entries[i].Reason = *reason
+		}
+	}
+
+	return c.printMaintenanceEntries(entries)
+}
@@ -0,0 +1,207 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func maintenanceEntities() []Entity {
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	return []Entity{
+		{ID: "e-1", Type: "Host", Name: "web-01", Tags: map[string]*string{"env": stringPtr("production")}},
+		{ID: "e-2", Type: "Host", Name: "web-02", InMaintenance: true, Tags: map[string]*string{MaintenanceUntilTag: stringPtr(past), MaintenanceReasonTag: stringPtr("deploy")}},
+		{ID: "e-3", Type: "Service", Name: "checkout", InMaintenance: true, Tags: map[string]*string{MaintenanceUntilTag: stringPtr(future)}},
+		{ID: "e-4", Type: "Service", Name: "cart", InMaintenance: true},
+	}
+}
+
+func TestStartMaintenance(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+
+	opts := &Options{IDs: []string{"e-1"}, Duration: 2 * time.Hour, Reason: "rollout"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	before := time.Now()
+	require.NoError(t, client.StartMaintenance(context.Background()))
+
+	entity := store.get("e-1")
+	require.True(t, entity.InMaintenance)
+	require.Equal(t, "production", *entity.Tags["env"])
+	require.Equal(t, "rollout", *entity.Tags[MaintenanceReasonTag])
+
+	until, ok := maintenanceUntil(&entity)
+	require.True(t, ok)
+	require.WithinDuration(t, before.Add(2*time.Hour), until, 5*time.Second)
+
+	require.Contains(t, testutil.ReadOutput(t, client.output), "Maintenance of entity e-1 started until ")
+}
+
+func TestStartMaintenanceBySelector(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Type: "Service", JSON: true, AutoApprove: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.StartMaintenance(context.Background()))
+
+	// e-3 gets its end time removed, e-4 is already in maintenance without one
+	require.Equal(t, 1, store.putCount())
+	_, ok := maintenanceUntil(ptr(store.get("e-3")))
+	require.False(t, ok)
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 2)
+
+	var result maintenanceResult
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &result))
+	require.Equal(t, "e-4", result.ID)
+	require.Equal(t, "unchanged", result.Status)
+}
+
+func TestStartMaintenanceBySelectorRequiresApproval(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+
+	// Without a terminal to confirm on, nothing is changed
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Type: "Service"})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.ErrorIs(t, client.StartMaintenance(context.Background()), shared.ErrNotApproved)
+
+	require.Zero(t, store.putCount())
+	require.Empty(t, testutil.ReadOutput(t, client.output))
+}
+
+func TestStopMaintenance(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, IDs: []string{"e-2"}})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.StopMaintenance(context.Background()))
+
+	entity := store.get("e-2")
+	require.False(t, entity.InMaintenance)
+	require.Empty(t, entity.Tags)
+	require.Contains(t, testutil.ReadOutput(t, client.output), "Maintenance of entity e-2 stopped")
+}
+
+func TestStopExpiredMaintenance(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Expired: true, AutoApprove: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.StopMaintenance(context.Background()))
+
+	require.Equal(t, 1, store.putCount())
+	require.False(t, store.get("e-2").InMaintenance)
+	require.True(t, store.get("e-3").InMaintenance)
+	require.True(t, store.get("e-4").InMaintenance)
+}
+
+func TestMaintenanceDryRun(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+
+	opts := &Options{IDs: []string{"e-1"}}
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.StartMaintenance(context.Background()))
+
+	require.Zero(t, store.putCount())
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, `"inMaintenance": true`)
+	require.Contains(t, output, "Dry run: maintenance of entity e-1 was not started")
+}
+
+func TestListMaintenance(t *testing.T) {
+	server, _ := newEntityServer(t, maintenanceEntities())
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.ListMaintenance(context.Background()))
+
+	output := testutil.ReadOutput(t, client.output)
+	require.NotContains(t, output, "e-1")
+	require.Contains(t, output, "ID: e-3")
+	require.Contains(t, output, "ID: e-4, Type: Service, Name: cart\n")
+
+	// Only the window whose end has passed is overdue
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.True(t, strings.HasPrefix(lines[0], "ID: e-2, Type: Host, Name: web-02, Until: "))
+	require.True(t, strings.HasSuffix(lines[0], ", Reason: deploy, Overdue: true"))
+	require.NotContains(t, lines[1], "Overdue")
+
+	client, err = NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Type: "Host"})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.ListMaintenance(context.Background()))
+	output = testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "ID: e-2")
+	require.NotContains(t, output, "e-3")
+}
+
+func TestListMaintenanceJSON(t *testing.T) {
+	server, _ := newEntityServer(t, maintenanceEntities())
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Type: "Host", JSON: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.ListMaintenance(context.Background()))
+
+	var entry maintenanceEntry
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &entry))
+	require.Equal(t, "e-2", entry.ID)
+	require.Equal(t, "deploy", entry.Reason)
+	require.True(t, entry.Overdue)
+}
+
+func TestStartMaintenanceMissingEntity(t *testing.T) {
+	server, _ := newEntityServer(t, maintenanceEntities())
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, IDs: []string{"e-404"}})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.Error(t, client.StartMaintenance(context.Background()))
+}
+
+func ptr(entity Entity) *Entity {
+	return &entity
+}
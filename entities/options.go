@@ -4,31 +4,76 @@
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/solarwinds/swo-cli/shared"
 )
 
 var (
-	errMissingEntityID   = errors.New("entity ID is required")
-	errMissingEntityType = errors.New("entity type is required")
-	errInvalidTag        = errors.New("invalid tag format, expected key=value")
-	errAtLeastOneTag     = errors.New("at least one tag is required for update")
+	errMissingEntityID    = errors.New("entity ID is required")
+	errMissingEntityType  = errors.New("entity type is required")
+	errInvalidTag         = errors.New("invalid tag format, expected key=value")
+	errAtLeastOneTag      = errors.New("at least one tag change is required for update")
+	errInvalidTimestamp   = errors.New("invalid timestamp, expected RFC3339 format")
+	errMissingFile        = errors.New("file is required")
+	errInvalidMatch       = errors.New("invalid match mode, expected id or name")
+	errSnapshotCount      = errors.New("expected one or two snapshot files")
+	errMissingSelector    = errors.New("either --id or --type is required")
+	errMissingStartTime   = errors.New("start time is required")
+	errMissingDuration    = errors.New("duration is required")
+	errInvalidDepth       = errors.New("depth must be at least 1")
+	errInvalidFormat      = errors.New("invalid output format")
+	errInvalidSample      = errors.New("sample size must be at least 1")
+	errInvalidStaleAfter  = errors.New("staleness threshold must be positive")
+	errConflictingAction  = errors.New("--delete and --tag cannot be used together")
+	errConflictingTarget  = errors.New("--id cannot be used together with --type or --name")
+	errInvalidConcurrency = errors.New("concurrency must be at least 1")
+	errOfflineExport      = errors.New("entities cannot be exported in offline mode, the cache does not keep attributes")
 )
 
 // Options represents the command line options for the entities command
 type Options struct {
 	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
 	ID                 string
+	IDs                []string
 	Type               string
 	Name               string
 	Tags               map[string]string
+	RemoveTags         []string
+	ReplaceTags        bool
+	IfUpdatedBefore    string
+	File               string
+	Out                string
+	Match              string
+	Merge              bool
+	Snapshots          []string
+	ExitCode           bool
+	Duration           time.Duration
+	Reason             string
+	Expired            bool
+	At                 time.Time
+	ScheduleFile       string
+	Interval           time.Duration
+	Once               bool
+	Depth              int
+	Format             string
+	Sample             int
+	StaleAfter         time.Duration
+	IncludeNeverSeen   bool
+	Delete             bool
+	Concurrency        int
+	AutoApprove        bool
+	CacheFile          string
+	CacheTTL           time.Duration
+	Offline            bool
 	JSON               bool
 }
 
 // NewOptions creates a new Options instance
 func NewOptions() *Options {
 	return &Options{
-		Tags: make(map[string]string),
+		Tags:     make(map[string]string),
+		CacheTTL: DefaultCacheTTL,
 	}
 }
 
@@ -50,9 +95,29 @@ func (o *Options) ParseTags(tagStrings []string) error {
 	return nil
 }
 
+// LoadTagFile merges tags from a YAML file into the options. Tags already
+// set with ParseTags take precedence over the ones from the file.
+func (o *Options) LoadTagFile(path string) error {
+	tags, err := loadTagFile(path)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range tags {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return fmt.Errorf("%w: empty key in %s", errInvalidTag, path)
+		}
+		if _, ok := o.Tags[key]; !ok {
+			o.Tags[key] = strings.TrimSpace(value)
+		}
+	}
+	return nil
+}
+
 // ValidateForGet validates the options for get operations
 func (o *Options) ValidateForGet() error {
-	if strings.TrimSpace(o.ID) == "" {
+	if strings.TrimSpace(o.ID) == "" && strings.TrimSpace(o.Name) == "" {
 		return errMissingEntityID
 	}
 	return nil
@@ -66,13 +131,141 @@ func (o *Options) ValidateForList() error {
 	return nil
 }
 
+// ValidateForExport validates options for export operation
+func (o *Options) ValidateForExport() error {
+	if o.Offline {
+		return errOfflineExport
+	}
+	return o.ValidateForList()
+}
+
 // ValidateForUpdate validates options for update operation
 func (o *Options) ValidateForUpdate() error {
-	if strings.TrimSpace(o.ID) == "" {
+	if strings.TrimSpace(o.ID) == "" && strings.TrimSpace(o.Name) == "" {
 		return errMissingEntityID
 	}
-	if len(o.Tags) == 0 {
+	if len(o.Tags) == 0 && len(o.RemoveTags) == 0 && !o.ReplaceTags {
 		return errAtLeastOneTag
 	}
+	if o.IfUpdatedBefore != "" {
+		if _, err := time.Parse(time.RFC3339, o.IfUpdatedBefore); err != nil {
+			return fmt.Errorf("%w: %s", errInvalidTimestamp, o.IfUpdatedBefore)
+		}
+	}
+	return nil
+}
+
+// ValidateForApply validates options for apply operation
+func (o *Options) ValidateForApply() error {
+	if strings.TrimSpace(o.File) == "" {
+		return errMissingFile
+	}
+	return nil
+}
+
+// ValidateForImport validates options for import operation
+func (o *Options) ValidateForImport() error {
+	if err := o.ValidateForApply(); err != nil {
+		return err
+	}
+	if o.Match != MatchByID && o.Match != MatchByName {
+		return fmt.Errorf("%w: %s", errInvalidMatch, o.Match)
+	}
+	return nil
+}
+
+// ValidateForDiff validates options for diff operation
+func (o *Options) ValidateForDiff() error {
+	if len(o.Snapshots) < 1 || len(o.Snapshots) > 2 {
+		return errSnapshotCount
+	}
+	if o.Match != MatchByID && o.Match != MatchByName {
+		return fmt.Errorf("%w: %s", errInvalidMatch, o.Match)
+	}
+	return nil
+}
+
+// ValidateForMaintenance validates options for maintenance start and stop operations
+func (o *Options) ValidateForMaintenance() error {
+	if o.Expired {
+		return nil
+	}
+	if len(o.IDs) == 0 && strings.TrimSpace(o.Type) == "" {
+		return errMissingSelector
+	}
+	if len(o.IDs) > 0 && (strings.TrimSpace(o.Type) != "" || strings.TrimSpace(o.Name) != "") {
+		return errConflictingTarget
+	}
+	for _, id := range o.IDs {
+		if strings.TrimSpace(id) == "" {
+			return errMissingEntityID
+		}
+	}
+	return nil
+}
+
+// ValidateForSchedule validates options for scheduling a maintenance window
+func (o *Options) ValidateForSchedule() error {
+	if o.At.IsZero() {
+		return errMissingStartTime
+	}
+	if o.Duration <= 0 {
+		return errMissingDuration
+	}
+	if strings.TrimSpace(o.ScheduleFile) == "" {
+		return errMissingFile
+	}
+	return o.ValidateForMaintenance()
+}
+
+// ValidateForRelated validates options for related operation
+func (o *Options) ValidateForRelated() error {
+	if err := o.ValidateForGet(); err != nil {
+		return err
+	}
+	if o.Depth < 1 {
+		return errInvalidDepth
+	}
+	switch o.Format {
+	case FormatTree, FormatDOT, FormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s, expected tree, dot or json", errInvalidFormat, o.Format)
+	}
+}
+
+// ValidateForDescribeType validates options for describe-type operation
+func (o *Options) ValidateForDescribeType() error {
+	if err := o.ValidateForList(); err != nil {
+		return err
+	}
+	if o.Sample < 1 {
+		return errInvalidSample
+	}
+	return nil
+}
+
+// ValidateForSummary validates options for summary operation
+func (o *Options) ValidateForSummary() error {
+	if o.StaleAfter <= 0 {
+		return errInvalidStaleAfter
+	}
+	return nil
+}
+
+// ValidateForStale validates options for stale operation
+func (o *Options) ValidateForStale() error {
+	if err := o.ValidateForList(); err != nil {
+		return err
+	}
+	if err := o.ValidateForSummary(); err != nil {
+		return err
+	}
+	if o.Delete && len(o.Tags) > 0 {
+		return errConflictingAction
+	}
+	if o.Concurrency < 1 {
+		return errInvalidConcurrency
+	}
 	return nil
 }
@@ -2,6 +2,7 @@
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -70,11 +71,14 @@ func TestValidation(t *testing.T) {
 
 	t.Run("ValidateForUpdate", func(t *testing.T) {
 		tests := []struct {
-			name        string
-			id          string
-			tags        map[string]string
-			expectError bool
-			expectedErr error
+			name            string
+			id              string
+			tags            map[string]string
+			removeTags      []string
+			replaceTags     bool
+			ifUpdatedBefore string
+			expectError     bool
+			expectedErr     error
 		}{
 			{
 				name:        "valid update",
@@ -102,6 +106,31 @@ func TestValidation(t *testing.T) {
 				tags:        map[string]string{},
 				expectError: true,
 			},
+			{
+				name:       "only removed tags",
+				id:         "e-1234567890",
+				tags:       map[string]string{},
+				removeTags: []string{"env"},
+			},
+			{
+				name:        "replace with empty tag set",
+				id:          "e-1234567890",
+				tags:        map[string]string{},
+				replaceTags: true,
+			},
+			{
+				name:            "valid if-updated-before",
+				id:              "e-1234567890",
+				tags:            map[string]string{"env": "production"},
+				ifUpdatedBefore: "2024-01-02T00:00:00Z",
+			},
+			{
+				name:            "invalid if-updated-before",
+				id:              "e-1234567890",
+				tags:            map[string]string{"env": "production"},
+				ifUpdatedBefore: "yesterday",
+				expectError:     true,
+			},
 		}
 
 		for _, tt := range tests {
@@ -109,6 +138,9 @@ func TestValidation(t *testing.T) {
 				opts := NewOptions()
 				opts.ID = tt.id
 				opts.Tags = tt.tags
+				opts.RemoveTags = tt.removeTags
+				opts.ReplaceTags = tt.replaceTags
+				opts.IfUpdatedBefore = tt.ifUpdatedBefore
 				err := opts.ValidateForUpdate()
 				if tt.expectError {
 					require.Error(t, err)
@@ -203,3 +235,168 @@ func TestParseTagsEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateForApply(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingFile, opts.ValidateForApply())
+
+	opts.File = "tags.yaml"
+	require.NoError(t, opts.ValidateForApply())
+}
+
+func TestValidateForExport(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingEntityType, opts.ValidateForExport())
+
+	opts.Type = "Host"
+	require.NoError(t, opts.ValidateForExport())
+
+	opts.Offline = true
+	require.Equal(t, errOfflineExport, opts.ValidateForExport())
+}
+
+func TestValidateForImport(t *testing.T) {
+	opts := NewOptions()
+	opts.Match = MatchByID
+	require.Equal(t, errMissingFile, opts.ValidateForImport())
+
+	opts.File = "hosts.jsonl"
+	require.NoError(t, opts.ValidateForImport())
+
+	opts.Match = MatchByName
+	require.NoError(t, opts.ValidateForImport())
+
+	opts.Match = "label"
+	require.ErrorIs(t, opts.ValidateForImport(), errInvalidMatch)
+}
+
+func TestValidateForDiff(t *testing.T) {
+	opts := NewOptions()
+	opts.Match = MatchByID
+	require.Equal(t, errSnapshotCount, opts.ValidateForDiff())
+
+	opts.Snapshots = []string{"a.jsonl"}
+	require.NoError(t, opts.ValidateForDiff())
+
+	opts.Snapshots = []string{"a.jsonl", "b.jsonl"}
+	require.NoError(t, opts.ValidateForDiff())
+
+	opts.Snapshots = []string{"a.jsonl", "b.jsonl", "c.jsonl"}
+	require.Equal(t, errSnapshotCount, opts.ValidateForDiff())
+
+	opts.Snapshots = []string{"a.jsonl"}
+	opts.Match = "label"
+	require.ErrorIs(t, opts.ValidateForDiff(), errInvalidMatch)
+}
+
+func TestValidateForMaintenance(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingSelector, opts.ValidateForMaintenance())
+
+	opts.IDs = []string{"e-1234567890"}
+	require.NoError(t, opts.ValidateForMaintenance())
+
+	opts.IDs = []string{" "}
+	require.Equal(t, errMissingEntityID, opts.ValidateForMaintenance())
+
+	opts.IDs = []string{"e-1234567890"}
+	opts.Type = "Host"
+	require.Equal(t, errConflictingTarget, opts.ValidateForMaintenance())
+
+	opts.Type = ""
+	opts.Name = "web-01"
+	require.Equal(t, errConflictingTarget, opts.ValidateForMaintenance())
+
+	opts.IDs = nil
+	opts.Type = "Host"
+	require.NoError(t, opts.ValidateForMaintenance())
+
+	opts.Name = ""
+
+	opts.Type = ""
+	opts.Expired = true
+	require.NoError(t, opts.ValidateForMaintenance())
+}
+
+func TestValidateForSchedule(t *testing.T) {
+	opts := NewOptions()
+	opts.IDs = []string{"e-1234567890"}
+	opts.ScheduleFile = "maintenance.yml"
+	require.Equal(t, errMissingStartTime, opts.ValidateForSchedule())
+
+	opts.At = time.Now()
+	require.Equal(t, errMissingDuration, opts.ValidateForSchedule())
+
+	opts.Duration = time.Hour
+	require.NoError(t, opts.ValidateForSchedule())
+
+	opts.IDs = nil
+	require.Equal(t, errMissingSelector, opts.ValidateForSchedule())
+}
+
+func TestValidateForRelated(t *testing.T) {
+	opts := NewOptions()
+	opts.Depth = 1
+	opts.Format = FormatTree
+	require.Equal(t, errMissingEntityID, opts.ValidateForRelated())
+
+	opts.ID = "e-1234567890"
+	require.NoError(t, opts.ValidateForRelated())
+
+	opts.Depth = 0
+	require.Equal(t, errInvalidDepth, opts.ValidateForRelated())
+
+	opts.Depth = 2
+	opts.Format = "svg"
+	require.ErrorIs(t, opts.ValidateForRelated(), errInvalidFormat)
+}
+
+func TestValidateByName(t *testing.T) {
+	opts := NewOptions()
+	opts.Name = "web-01"
+	require.NoError(t, opts.ValidateForGet())
+
+	opts.Tags = map[string]string{"env": "prod"}
+	require.NoError(t, opts.ValidateForUpdate())
+
+	opts.Name = "  "
+	require.Equal(t, errMissingEntityID, opts.ValidateForGet())
+}
+
+func TestValidateForDescribeType(t *testing.T) {
+	opts := NewOptions()
+	opts.Sample = DefaultSampleSize
+	require.Equal(t, errMissingEntityType, opts.ValidateForDescribeType())
+
+	opts.Type = "Host"
+	require.NoError(t, opts.ValidateForDescribeType())
+
+	opts.Sample = 0
+	require.Equal(t, errInvalidSample, opts.ValidateForDescribeType())
+}
+
+func TestValidateForSummary(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errInvalidStaleAfter, opts.ValidateForSummary())
+
+	opts.StaleAfter = DefaultStaleAfter
+	require.NoError(t, opts.ValidateForSummary())
+}
+
+func TestValidateForStale(t *testing.T) {
+	opts := NewOptions()
+	opts.StaleAfter = DefaultStaleAfter
+	opts.Concurrency = DefaultConcurrency
+	require.Equal(t, errMissingEntityType, opts.ValidateForStale())
+
+	opts.Type = "Host"
+	require.NoError(t, opts.ValidateForStale())
+
+	opts.Delete = true
+	opts.Tags = map[string]string{"stale": "true"}
+	require.Equal(t, errConflictingAction, opts.ValidateForStale())
+
+	opts.Delete = false
+	opts.Concurrency = 0
+	require.Equal(t, errInvalidConcurrency, opts.ValidateForStale())
+}
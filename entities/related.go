@@ -0,0 +1,231 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// FormatTree renders related entities as an indented tree
+	FormatTree = "tree"
+	// FormatDOT renders related entities as a Graphviz digraph
+	FormatDOT = "dot"
+	// FormatJSON renders related entities as nested JSON
+	FormatJSON = "json"
+)
+
+// Relationship is a link from an entity to a related entity
+type Relationship struct {
+	Type   string `json:"type"`
+	Entity Entity `json:"entity"`
+}
+
+type listRelationshipsResponse struct {
+	Relationships []Relationship `json:"relationships"`
+	pageInfo      `json:"pageInfo"`
+}
+
+// relatedNode is an entity in the relationship tree. Entities reachable on several
+// paths are expanded only at their first occurrence.
+type relatedNode struct {
+	ID       string         `json:"id"`
+	Type     string         `json:"type"`
+	Name     string         `json:"name,omitempty"`
+	Relation string         `json:"relation,omitempty"`
+	Repeated bool           `json:"repeated,omitempty"`
+	Children []*relatedNode `json:"children,omitempty"`
+}
+
+type relatedEdge struct {
+	From     string
+	To       string
+	Relation string
+}
+
+type relatedGraph struct {
+	Root  *relatedNode
+	Nodes map[string]*relatedNode
+	Edges []relatedEdge
+}
+
+func (c *Client) prepareRelationshipsRequest(ctx context.Context, id string, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/entities", id, "relationships")
+}
+
+// fetchRelationships retrieves all relationships of an entity
+func (c *Client) fetchRelationships(ctx context.Context, id string) ([]Relationship, error) {
+	var result []Relationship
+	var nextPage string
+
+	for {
+		request, err := c.prepareRelationshipsRequest(ctx, id, nextPage)
+		if err != nil {
+			return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+		}
+
+		content, err := c.doRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(content) == 0 {
+			return nil, ErrNoContent
+		}
+
+		var response listRelationshipsResponse
+		if err := json.Unmarshal(content, &response); err != nil {
+			return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+		}
+
+		result = append(result, response.Relationships...)
+
+		if response.NextPage == "" {
+			return result, nil
+		}
+		nextPage = response.NextPage
+	}
+}
+
+// walkRelated visits related entities breadth-first up to the given depth
+func (c *Client) walkRelated(ctx context.Context, root *Entity, depth int) (*relatedGraph, error) {
+	graph := &relatedGraph{
+		Root:  &relatedNode{ID: root.ID, Type: root.Type, Name: root.Name},
+		Nodes: make(map[string]*relatedNode),
+	}
+	graph.Nodes[root.ID] = graph.Root
+
+	level := []*relatedNode{graph.Root}
+	for d := 0; d < depth && len(level) > 0; d++ {
+		var next []*relatedNode
+		for _, node := range level {
+			relationships, err := c.fetchRelationships(ctx, node.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get relationships of entity %s: %w", node.ID, err)
+			}
+
+			for _, relationship := range relationships {
+				related := relationship.Entity
+				graph.Edges = append(graph.Edges, relatedEdge{From: node.ID, To: related.ID, Relation: relationship.Type})
+
+				child := &relatedNode{ID: related.ID, Type: related.Type, Name: related.Name, Relation: relationship.Type}
+				if _, seen := graph.Nodes[related.ID]; seen {
+					child.Repeated = true
+				} else {
+					graph.Nodes[related.ID] = child
+					next = append(next, child)
+				}
+				node.Children = append(node.Children, child)
+			}
+		}
+		level = next
+	}
+
+	return graph, nil
+}
+
+func (n *relatedNode) label() string {
+	label := n.Type
+	if n.Name != "" {
+		label += " " + n.Name
+	}
+	return fmt.Sprintf("%s (%s)", label, n.ID)
+}
+
+func writeTree(w io.Writer, node *relatedNode, prefix string) {
+	for i, child := range node.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		line := child.label()
+		if child.Relation != "" {
+			line = child.Relation + ": " + line
+		}
+		if child.Repeated {
+			line += " [see above]"
+		}
+		_, _ = fmt.Fprintf(w, "%s%s%s\n", prefix, branch, line)
+
+		writeTree(w, child, prefix+indent)
+	}
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func dotQuote(s string) string {
+	return `"` + dotEscape(s) + `"`
+}
+
+func writeDOT(w io.Writer, graph *relatedGraph) {
+	_, _ = fmt.Fprintln(w, "digraph related {")
+	_, _ = fmt.Fprintln(w, "  node [shape=box];")
+
+	written := make(map[string]bool)
+	var writeNodes func(node *relatedNode)
+	writeNodes = func(node *relatedNode) {
+		if node.Repeated || written[node.ID] {
+			return
+		}
+		written[node.ID] = true
+
+		// Type and name are shown on separate lines of the box
+		label := dotEscape(node.Type)
+		if node.Name != "" {
+			label += `\n` + dotEscape(node.Name)
+		}
+		_, _ = fmt.Fprintf(w, "  %s [label=\"%s\"];\n", dotQuote(node.ID), label)
+		for _, child := range node.Children {
+			writeNodes(child)
+		}
+	}
+	writeNodes(graph.Root)
+
+	for _, edge := range graph.Edges {
+		_, _ = fmt.Fprintf(w, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Relation))
+	}
+	_, _ = fmt.Fprintln(w, "}")
+}
+
+// Related walks the relationships of an entity and renders them as a tree, a
+// Graphviz digraph or JSON
+func (c *Client) Related(ctx context.Context) error {
+	root, err := c.getEntity(ctx, c.opts.ID)
+	if err != nil {
+		return err
+	}
+
+	graph, err := c.walkRelated(ctx, root, c.opts.Depth)
+	if err != nil {
+		return err
+	}
+
+	switch c.opts.Format {
+	case FormatDOT:
+		writeDOT(c.output, graph)
+	case FormatJSON:
+		jsonData, err := json.Marshal(graph.Root)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+	default:
+		_, _ = fmt.Fprintln(c.output, graph.Root.label())
+		writeTree(c.output, graph.Root, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func newRelatedServer(t *testing.T) *httptest.Server {
+	entities := map[string]Entity{
+		"e-1": {ID: "e-1", Type: "Host", Name: "web-01"},
+		"e-2": {ID: "e-2", Type: "Container", Name: "app-1"},
+		"e-3": {ID: "e-3", Type: "Container", Name: "app-2"},
+		"e-4": {ID: "e-4", Type: "Service", Name: "checkout"},
+		"e-5": {ID: "e-5", Type: "Database", Name: "orders"},
+	}
+	relationships := map[string][]Relationship{
+		"e-1": {{Type: "contains", Entity: entities["e-2"]}, {Type: "contains", Entity: entities["e-3"]}},
+		"e-2": {{Type: "runs", Entity: entities["e-4"]}},
+		"e-3": {{Type: "runs", Entity: entities["e-4"]}},
+		"e-4": {{Type: "calls", Entity: entities["e-5"]}, {Type: "runsOn", Entity: entities["e-1"]}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		path := strings.TrimPrefix(r.URL.Path, "/v1/entities/")
+		id, isRelationships := strings.CutSuffix(path, "/relationships")
+
+		var response interface{}
+		if isRelationships {
+			// Return the relationships in pages of one to exercise pagination
+			offset := 0
+			if r.URL.Query().Get("offset") == "1" {
+				offset = 1
+			}
+			page := listRelationshipsResponse{Relationships: []Relationship{}}
+			if offset < len(relationships[id]) {
+				page.Relationships = relationships[id][offset : offset+1]
+			}
+			if offset+1 < len(relationships[id]) {
+				page.NextPage = "/v1/entities/" + id + "/relationships?offset=1"
+			}
+			response = page
+		} else {
+			entity, ok := entities[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			response = entity
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRelatedTree(t *testing.T) {
+	server := newRelatedServer(t)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, ID: "e-1", Depth: 3, Format: FormatTree})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Related(context.Background()))
+
+	expected := `Host web-01 (e-1)
+├── contains: Container app-1 (e-2)
+│   └── runs: Service checkout (e-4)
+│       ├── calls: Database orders (e-5)
+│       └── runsOn: Host web-01 (e-1) [see above]
+└── contains: Container app-2 (e-3)
+    └── runs: Service checkout (e-4) [see above]
+`
+	require.Equal(t, expected, testutil.ReadOutput(t, client.output))
+}
+
+func TestRelatedDepth(t *testing.T) {
+	server := newRelatedServer(t)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, ID: "e-1", Depth: 1, Format: FormatJSON})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Related(context.Background()))
+
+	var root relatedNode
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &root))
+	require.Equal(t, "e-1", root.ID)
+	require.Len(t, root.Children, 2)
+	require.Equal(t, "contains", root.Children[0].Relation)
+	require.Empty(t, root.Children[0].Children)
+}
+
+func TestRelatedDOT(t *testing.T) {
+	server := newRelatedServer(t)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, ID: "e-2", Depth: 2, Format: FormatDOT})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Related(context.Background()))
+
+	expected := `digraph related {
+  node [shape=box];
+  "e-2" [label="Container\napp-1"];
+  "e-4" [label="Service\ncheckout"];
+  "e-5" [label="Database\norders"];
+  "e-1" [label="Host\nweb-01"];
+  "e-2" -> "e-4" [label="runs"];
+  "e-4" -> "e-5" [label="calls"];
+  "e-4" -> "e-1" [label="runsOn"];
+}
+`
+	require.Equal(t, expected, testutil.ReadOutput(t, client.output))
+}
+
+func TestRelatedMissingEntity(t *testing.T) {
+	server := newRelatedServer(t)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, ID: "e-404", Depth: 1, Format: FormatTree})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.Error(t, client.Related(context.Background()))
+}
+
+func TestDOTQuote(t *testing.T) {
+	require.Equal(t, `"say \"hi\" \\o/"`, dotQuote(`say "hi" \o/`))
+}
@@ -0,0 +1,308 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultScheduleFile is the default path of the maintenance schedule file
+	DefaultScheduleFile = "~/.swo-cli-maintenance.yml"
+	// DefaultSchedulerInterval is how often the scheduler checks the schedule file
+	DefaultSchedulerInterval = 30 * time.Second
+
+	windowPending = "pending"
+	windowActive  = "active"
+	windowDone    = "done"
+	windowMissed  = "missed"
+	windowFailed  = "failed"
+)
+
+// maintenanceWindow is a scheduled maintenance of the entities matched by its selector
+type maintenanceWindow struct {
+	ID       string    `yaml:"id"`
+	Start    time.Time `yaml:"start"`
+	Duration string    `yaml:"duration"`
+	Reason   string    `yaml:"reason,omitempty"`
+	IDs      []string  `yaml:"ids,omitempty"`
+	Type     string    `yaml:"type,omitempty"`
+	Name     string    `yaml:"name,omitempty"`
+	State    string    `yaml:"state"`
+	Error    string    `yaml:"error,omitempty"`
+	// Started are the entities this window put into maintenance, only they are
+	// taken out of maintenance when the window ends
+	Started []string `yaml:"started,omitempty"`
+}
+
+type maintenanceSchedule struct {
+	Windows []maintenanceWindow `yaml:"windows"`
+}
+
+func (w *maintenanceWindow) end() (time.Time, error) {
+	d, err := shared.ParseDuration(w.Duration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return w.Start.Add(d), nil
+}
+
+func loadSchedule(path string) (*maintenanceSchedule, error) {
+	schedule := &maintenanceSchedule{}
+
+	content, err := os.ReadFile(path) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return schedule, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(content, schedule); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling %s schedule: %w", path, err)
+	}
+
+	return schedule, nil
+}
+
+// saveSchedule writes the schedule to a temporary file first, so a concurrently
+// running scheduler never reads a partially written file
+func saveSchedule(path string, schedule *maintenanceSchedule) error {
+	content, err := yaml.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".swo-cli-maintenance-*")
+	if err != nil {
+		return fmt.Errorf("failed to write schedule %s: %w", path, err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write schedule %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write schedule %s: %w", path, err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// ScheduleMaintenance adds a maintenance window to the schedule file. Windows are
+// started and stopped by a running 'maintenance run' process.
+func (c *Client) ScheduleMaintenance(_ context.Context) error {
+	schedule, err := loadSchedule(c.opts.ScheduleFile)
+	if err != nil {
+		return err
+	}
+
+	// Relative times like "in 1 hour" carry sub-second precision from the clock
+	start := c.opts.At.Truncate(time.Second)
+	window := maintenanceWindow{
+		ID:       fmt.Sprintf("%s-%d", start.UTC().Format("20060102T150405Z"), len(schedule.Windows)+1),
+		Start:    start,
+		Duration: c.opts.Duration.String(),
+		Reason:   c.opts.Reason,
+		IDs:      c.opts.IDs,
+		Type:     c.opts.Type,
+		Name:     c.opts.Name,
+		State:    windowPending,
+	}
+	schedule.Windows = append(schedule.Windows, window)
+
+	if err := saveSchedule(c.opts.ScheduleFile, schedule); err != nil {
+		return err
+	}
+
+	end, _ := window.end()
+	_, _ = fmt.Fprintf(c.output, "Scheduled maintenance %s from %s to %s in %s\n",
+		window.ID, window.Start.Format(time.RFC3339), end.Format(time.RFC3339), c.opts.ScheduleFile)
+
+	return nil
+}
+
+// runWindow moves a window to its next state if its start or end time has come
+func (c *Client) runWindow(ctx context.Context, window *maintenanceWindow, now time.Time) {
+	end, err := window.end()
+	if err != nil {
+		window.State = windowFailed
+		window.Error = err.Error()
+		return
+	}
+
+	var enabled bool
+	switch {
+	case window.State == windowPending && !now.Before(end):
+		slog.Warn("Maintenance window ended before it could be started", "window", window.ID)
+		window.State = windowMissed
+		return
+	case window.State == windowPending && !now.Before(window.Start):
+		enabled = true
+		err = c.startWindow(ctx, window, end)
+	case window.State == windowActive && !now.Before(end):
+		enabled = false
+		err = c.stopWindow(ctx, window)
+	default:
+		return
+	}
+
+	if err != nil {
+		// Keep the state, so the transition is retried on the next run
+		slog.Error("Failed to change maintenance", "window", window.ID, "error", err)
+		window.Error = err.Error()
+		return
+	}
+
+	window.Error = ""
+	if enabled {
+		window.State = windowActive
+	} else {
+		window.State = windowDone
+	}
+}
+
+// startWindow puts the entities matched by the window into maintenance and records
+// the ones that were not in maintenance before
+func (c *Client) startWindow(ctx context.Context, window *maintenanceWindow, end time.Time) error {
+	entities, err := c.selectEntities(ctx, window.IDs, window.Type, window.Name)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, entity := range entities {
+		result := c.setMaintenance(ctx, entity, true, end, window.Reason)
+		switch {
+		case result.Status == "failed":
+			failed++
+		case !entity.InMaintenance && !slices.Contains(window.Started, entity.ID):
+			window.Started = append(window.Started, entity.ID)
+		}
+		if err := c.printMaintenanceResult(result); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errMaintenanceFailed, failed, len(entities))
+	}
+	return nil
+}
+
+// stopWindow takes the entities the window started out of maintenance. Entities
+// matched by the selector in the meantime, or put into maintenance by someone
+// else, are left alone.
+func (c *Client) stopWindow(ctx context.Context, window *maintenanceWindow) error {
+	failed := 0
+	for _, id := range window.Started {
+		entity, err := c.getEntity(ctx, id)
+		if errors.Is(err, errNotFound) {
+			slog.Warn("Entity of maintenance window no longer exists", "window", window.ID, "id", id)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get entity %s: %w", id, err)
+		}
+
+		// A dry run did not start the maintenance, show the stop as if it had
+		if c.opts.DryRun {
+			entity.InMaintenance = true
+		}
+
+		result := c.setMaintenance(ctx, *entity, false, time.Time{}, "")
+		if result.Status == "failed" {
+			failed++
+		}
+		if err := c.printMaintenanceResult(result); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errMaintenanceFailed, failed, len(window.Started))
+	}
+	return nil
+}
+
+// runSchedule processes all windows of the schedule file once. The file is read
+// again before saving, so windows added in the meantime are kept. During a dry
+// run nothing is changed in SWO, so the states are kept in simulated instead of
+// the file, and each transition is shown once.
+func (c *Client) runSchedule(ctx context.Context, now time.Time, simulated map[string]maintenanceWindow) error {
+	schedule, err := loadSchedule(c.opts.ScheduleFile)
+	if err != nil {
+		return err
+	}
+
+	changed := make(map[string]maintenanceWindow)
+	for _, window := range schedule.Windows {
+		if state, ok := simulated[window.ID]; ok && c.opts.DryRun {
+			window = state
+		}
+		state, errorMessage, started := window.State, window.Error, slices.Clone(window.Started)
+		c.runWindow(ctx, &window, now)
+		if window.State != state || window.Error != errorMessage || !slices.Equal(window.Started, started) {
+			changed[window.ID] = window
+		}
+	}
+
+	if c.opts.DryRun {
+		for id, window := range changed {
+			simulated[id] = window
+		}
+		return nil
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	schedule, err = loadSchedule(c.opts.ScheduleFile)
+	if err != nil {
+		return err
+	}
+	for i := range schedule.Windows {
+		if window, ok := changed[schedule.Windows[i].ID]; ok {
+			schedule.Windows[i] = window
+		}
+	}
+
+	return saveSchedule(c.opts.ScheduleFile, schedule)
+}
+
+// RunScheduler starts and stops scheduled maintenance windows until the context is
+// cancelled, or processes the schedule a single time with --once
+func (c *Client) RunScheduler(ctx context.Context) error {
+	slog.Info("Running maintenance scheduler", "schedule", c.opts.ScheduleFile, "interval", c.opts.Interval)
+
+	simulated := make(map[string]maintenanceWindow)
+	for {
+		if err := c.runSchedule(ctx, time.Now(), simulated); err != nil {
+			if c.opts.Once {
+				return err
+			}
+			slog.Error("Failed to process maintenance schedule", "error", err)
+		}
+
+		if c.opts.Once {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.opts.Interval):
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package entities
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleMaintenance(t *testing.T) {
+	scheduleFile := filepath.Join(t.TempDir(), "maintenance.yml")
+	start := time.Date(2030, 1, 2, 23, 0, 0, 0, time.UTC)
+
+	opts := &Options{IDs: []string{"e-1"}, At: start.Add(550 * time.Millisecond), Duration: time.Hour, Reason: "upgrade", ScheduleFile: scheduleFile}
+	opts.Token = "test-token"
+	opts.APIURL = "http://127.0.0.1:0"
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.ScheduleMaintenance(context.Background()))
+
+	opts = &Options{Type: "Host", Name: "web-02", At: start.Add(time.Hour), Duration: 30 * time.Minute, ScheduleFile: scheduleFile}
+	opts.Token = "test-token"
+	opts.APIURL = "http://127.0.0.1:0"
+	client, err = NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.ScheduleMaintenance(context.Background()))
+	require.Contains(t, testutil.ReadOutput(t, client.output), "Scheduled maintenance 20300103T000000Z-2 from 2030-01-03T00:00:00Z to 2030-01-03T00:30:00Z")
+
+	schedule, err := loadSchedule(scheduleFile)
+	require.NoError(t, err)
+	require.Len(t, schedule.Windows, 2)
+	require.Equal(t, maintenanceWindow{
+		ID:       "20300102T230000Z-1",
+		Start:    start,
+		Duration: "1h0m0s",
+		Reason:   "upgrade",
+		IDs:      []string{"e-1"},
+		State:    windowPending,
+	}, schedule.Windows[0])
+	require.Equal(t, "Host", schedule.Windows[1].Type)
+	require.Equal(t, "web-02", schedule.Windows[1].Name)
+}
+
+func TestLoadMissingSchedule(t *testing.T) {
+	schedule, err := loadSchedule(filepath.Join(t.TempDir(), "missing.yml"))
+	require.NoError(t, err)
+	require.Empty(t, schedule.Windows)
+}
+
+func TestRunSchedule(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+	scheduleFile := filepath.Join(t.TempDir(), "maintenance.yml")
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	require.NoError(t, saveSchedule(scheduleFile, &maintenanceSchedule{Windows: []maintenanceWindow{
+		{ID: "w-1", Start: start, Duration: "1h", Reason: "upgrade", IDs: []string{"e-1"}, State: windowPending},
+		{ID: "w-2", Start: start.Add(-3 * time.Hour), Duration: "1h", IDs: []string{"e-1"}, State: windowPending},
+		{ID: "w-3", Start: start, Duration: "soon", IDs: []string{"e-1"}, State: windowPending},
+	}}))
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, ScheduleFile: scheduleFile})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	states := func() []string {
+		schedule, err := loadSchedule(scheduleFile)
+		require.NoError(t, err)
+		var result []string
+		for _, window := range schedule.Windows {
+			result = append(result, window.State)
+		}
+		return result
+	}
+
+	// Before the window nothing happens except for the missed and invalid windows
+	require.NoError(t, client.runSchedule(context.Background(), start.Add(-time.Minute), nil))
+	require.Equal(t, []string{windowPending, windowMissed, windowFailed}, states())
+	require.False(t, store.get("e-1").InMaintenance)
+
+	// The window starts
+	require.NoError(t, client.runSchedule(context.Background(), start, nil))
+	require.Equal(t, []string{windowActive, windowMissed, windowFailed}, states())
+	entity := store.get("e-1")
+	require.True(t, entity.InMaintenance)
+	require.Equal(t, "upgrade", *entity.Tags[MaintenanceReasonTag])
+	until, ok := maintenanceUntil(&entity)
+	require.True(t, ok)
+	require.True(t, until.Equal(start.Add(time.Hour)))
+
+	// Running again in the middle of the window is a no-op
+	require.NoError(t, client.runSchedule(context.Background(), start.Add(30*time.Minute), nil))
+	require.Equal(t, 1, store.putCount())
+
+	// The window ends
+	require.NoError(t, client.runSchedule(context.Background(), start.Add(time.Hour), nil))
+	require.Equal(t, []string{windowDone, windowMissed, windowFailed}, states())
+	require.False(t, store.get("e-1").InMaintenance)
+	require.Equal(t, 2, store.putCount())
+}
+
+func TestRunScheduleRetriesFailures(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+	scheduleFile := filepath.Join(t.TempDir(), "maintenance.yml")
+	start := time.Now().Truncate(time.Second)
+
+	require.NoError(t, saveSchedule(scheduleFile, &maintenanceSchedule{Windows: []maintenanceWindow{
+		{ID: "w-1", Start: start, Duration: "1h", IDs: []string{"e-404"}, State: windowPending},
+	}}))
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, ScheduleFile: scheduleFile})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.runSchedule(context.Background(), start, nil))
+
+	schedule, err := loadSchedule(scheduleFile)
+	require.NoError(t, err)
+	require.Equal(t, windowPending, schedule.Windows[0].State)
+	require.Contains(t, schedule.Windows[0].Error, "e-404")
+	require.Zero(t, store.putCount())
+}
+
+func TestRunScheduleStopsOnlyStartedEntities(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+	scheduleFile := filepath.Join(t.TempDir(), "maintenance.yml")
+	start := time.Now().Truncate(time.Second)
+
+	require.NoError(t, saveSchedule(scheduleFile, &maintenanceSchedule{Windows: []maintenanceWindow{
+		{ID: "w-1", Start: start, Duration: "1h", Type: "Host", State: windowPending},
+	}}))
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, ScheduleFile: scheduleFile})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.runSchedule(context.Background(), start, nil))
+
+	schedule, err := loadSchedule(scheduleFile)
+	require.NoError(t, err)
+	require.Equal(t, []string{"e-1"}, schedule.Windows[0].Started)
+
+	// e-2 was in maintenance before the window, so it stays in maintenance
+	require.NoError(t, client.runSchedule(context.Background(), start.Add(time.Hour), nil))
+	require.False(t, store.get("e-1").InMaintenance)
+	require.True(t, store.get("e-2").InMaintenance)
+}
+
+func TestRunScheduleDryRun(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+	scheduleFile := filepath.Join(t.TempDir(), "maintenance.yml")
+	start := time.Now().Truncate(time.Second)
+
+	require.NoError(t, saveSchedule(scheduleFile, &maintenanceSchedule{Windows: []maintenanceWindow{
+		{ID: "w-1", Start: start, Duration: "1h", IDs: []string{"e-1"}, State: windowPending},
+	}}))
+
+	opts := &Options{ScheduleFile: scheduleFile}
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	// The simulated state is kept between runs, so the start is shown once
+	simulated := make(map[string]maintenanceWindow)
+	require.NoError(t, client.runSchedule(context.Background(), start, simulated))
+	require.NoError(t, client.runSchedule(context.Background(), start.Add(time.Minute), simulated))
+	require.NoError(t, client.runSchedule(context.Background(), start.Add(time.Hour), simulated))
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Equal(t, 1, strings.Count(output, "Dry run: maintenance of entity e-1 was not started"))
+	require.Equal(t, 1, strings.Count(output, "Dry run: maintenance of entity e-1 was not stopped"))
+	require.Zero(t, store.putCount())
+
+	schedule, err := loadSchedule(scheduleFile)
+	require.NoError(t, err)
+	require.Equal(t, windowPending, schedule.Windows[0].State)
+}
+
+func TestRunSchedulerOnce(t *testing.T) {
+	server, store := newEntityServer(t, maintenanceEntities())
+	scheduleFile := filepath.Join(t.TempDir(), "maintenance.yml")
+
+	require.NoError(t, saveSchedule(scheduleFile, &maintenanceSchedule{Windows: []maintenanceWindow{
+		{ID: "w-1", Start: time.Now().Add(-time.Minute), Duration: "1h", Type: "Host", Name: "web-01", State: windowPending},
+	}}))
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, ScheduleFile: scheduleFile, Once: true, Interval: time.Hour})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.RunScheduler(context.Background()))
+	require.True(t, store.get("e-1").InMaintenance)
+}
@@ -0,0 +1,201 @@
+package entities
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// MatchByID matches snapshot entities to live entities by their ID
+	MatchByID = "id"
+	// MatchByName matches snapshot entities to live entities by their type and name,
+	// which is useful for entities that were recreated or live in another organization
+	MatchByName = "name"
+
+	maxSnapshotLineSize = 10 * 1024 * 1024
+)
+
+var (
+	errNoMatchingEntity  = errors.New("no matching entity found")
+	errAmbiguousEntity   = errors.New("more than one entity matches")
+	errMissingEntityName = errors.New("snapshot entity has no name")
+)
+
+// readSnapshot reads entities from a JSON lines snapshot as written by Export
+func readSnapshot(r io.Reader) ([]Entity, error) {
+	var entities []Entity
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSnapshotLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var entity Entity
+		if err := json.Unmarshal([]byte(text), &entity); err != nil {
+			return nil, fmt.Errorf("error while unmarshaling snapshot line %d: %w", line, err)
+		}
+		entities = append(entities, entity)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	return entities, nil
+}
+
+func readSnapshotFile(path string) ([]Entity, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot %s: %w", path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return readSnapshot(f)
+}
+
+// writeEntities writes all entities of the given type as JSON lines and returns
+// how many were written
+func (c *Client) writeEntities(ctx context.Context, w io.Writer) (int, error) {
+	encoder := json.NewEncoder(w)
+	count := 0
+	err := c.listEntities(ctx, c.opts.Type, c.opts.Name, func(entities []Entity) error {
+		for _, entity := range entities {
+			if err := encoder.Encode(entity); err != nil {
+				return fmt.Errorf("failed to write entity %s: %w", entity.ID, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Export writes all entities of the given type, including tags and attributes,
+// as JSON lines to the output file or standard output
+func (c *Client) Export(ctx context.Context) error {
+	if c.opts.Out == "" {
+		_, err := c.writeEntities(ctx, c.output)
+		return err
+	}
+
+	f, err := os.Create(c.opts.Out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", c.opts.Out, err)
+	}
+
+	count, err := c.writeEntities(ctx, f)
+	// Writes may only fail when the file is closed, e.g. on a full disk
+	if closeErr := f.Close(); closeErr != nil && err == nil {
+		err = fmt.Errorf("failed to write %s: %w", c.opts.Out, closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !c.opts.JSON {
+		_, _ = fmt.Fprintf(c.output, "Exported %d entities to %s\n", count, c.opts.Out)
+	}
+
+	return nil
+}
+
+// matcher finds the live entities corresponding to snapshot entities. Matching by
+// name lists every type once and indexes its entities by name.
+type matcher struct {
+	client *Client
+	byName map[string]map[string][]Entity
+}
+
+func (c *Client) newMatcher() *matcher {
+	return &matcher{client: c, byName: make(map[string]map[string][]Entity)}
+}
+
+// match finds the live entity corresponding to a snapshot entity
+func (m *matcher) match(ctx context.Context, snapshot *Entity) (*Entity, error) {
+	if m.client.opts.Match != MatchByName {
+		return m.client.getEntity(ctx, snapshot.ID)
+	}
+
+	if snapshot.Name == "" {
+		return nil, errMissingEntityName
+	}
+
+	names, ok := m.byName[snapshot.Type]
+	if !ok {
+		entities, err := m.client.fetchEntities(ctx, snapshot.Type, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list entities of type %s: %w", snapshot.Type, err)
+		}
+		names = make(map[string][]Entity)
+		for _, entity := range entities {
+			names[entity.Name] = append(names[entity.Name], entity)
+		}
+		m.byName[snapshot.Type] = names
+	}
+
+	matched := names[snapshot.Name]
+	switch len(matched) {
+	case 0:
+		return nil, errNoMatchingEntity
+	case 1:
+		return &matched[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %d entities of type %s are named %s", errAmbiguousEntity, len(matched), snapshot.Type, snapshot.Name)
+	}
+}
+
+// skippable reports whether a snapshot entity failed to match because of the
+// entity itself, rather than because the API could not be reached
+func skippable(err error) bool {
+	return errors.Is(err, errNoMatchingEntity) || errors.Is(err, errAmbiguousEntity) ||
+		errors.Is(err, errMissingEntityName) || errors.Is(err, errNotFound)
+}
+
+// Import restores tags from a snapshot written by Export. Snapshot entities that
+// cannot be matched to a live entity are reported and skipped, any other error
+// stops the import before anything is changed.
+func (c *Client) Import(ctx context.Context) error {
+	snapshot, err := readSnapshotFile(c.opts.File)
+	if err != nil {
+		return err
+	}
+
+	tagOpts := &Options{ReplaceTags: !c.opts.Merge}
+	p := newPlanner()
+	m := c.newMatcher()
+	var skipped []skippedEntity
+	for i := range snapshot {
+		entity, err := m.match(ctx, &snapshot[i])
+		if err != nil {
+			if !skippable(err) {
+				return fmt.Errorf("failed to match snapshot entity %s: %w", snapshot[i].ID, err)
+			}
+			skipped = append(skipped, skippedEntity{ID: snapshot[i].ID, Type: snapshot[i].Type, Name: snapshot[i].Name, Error: err.Error()})
+			if !c.opts.JSON {
+				_, _ = fmt.Fprintf(c.output, "Skipping %s (%s %s): %v\n", snapshot[i].ID, snapshot[i].Type, snapshot[i].Name, err)
+			}
+			continue
+		}
+
+		tagOpts.Tags = make(map[string]string)
+		for key, value := range snapshot[i].Tags {
+			tagOpts.Tags[key] = tagValue(value)
+		}
+		p.add(*entity, tagOpts)
+	}
+
+	return c.execute(ctx, p.finish(), skipped)
+}
@@ -0,0 +1,191 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	server, store := newEntityServer(t, append(append([]Entity{}, applyEntities...), testEntities...))
+	store.pageSize = 1
+
+	out := filepath.Join(t.TempDir(), "hosts.jsonl")
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Type: "Host", Out: out})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Export(context.Background()))
+	require.Contains(t, testutil.ReadOutput(t, client.output), "Exported 2 entities to "+out)
+
+	f, err := os.Open(out)
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	snapshot, err := readSnapshot(f)
+	require.NoError(t, err)
+	require.Equal(t, applyEntities[:2], snapshot)
+}
+
+func TestExportWriteError(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full is not available")
+	}
+	server, _ := newEntityServer(t, applyEntities)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Type: "Host", Out: "/dev/full"})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.Error(t, client.Export(context.Background()))
+	require.NotContains(t, testutil.ReadOutput(t, client.output), "Exported")
+}
+
+func TestExportToStdout(t *testing.T) {
+	server, _ := newEntityServer(t, testEntities)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, Type: "SyslogHost"})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Export(context.Background()))
+
+	snapshot, err := readSnapshot(strings.NewReader(testutil.ReadOutput(t, client.output)))
+	require.NoError(t, err)
+	require.Equal(t, testEntities[:1], snapshot)
+	require.Equal(t, "test-host-1.example.com", snapshot[0].Attributes["hostname"])
+}
+
+func TestReadSnapshot(t *testing.T) {
+	snapshot, err := readSnapshot(strings.NewReader("{\"id\":\"e-1\",\"type\":\"Host\"}\n\n{\"id\":\"e-2\",\"type\":\"Host\"}\n"))
+	require.NoError(t, err)
+	require.Len(t, snapshot, 2)
+
+	_, err = readSnapshot(strings.NewReader("{\"id\":\"e-1\"}\nnot json\n"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+}
+
+func writeSnapshot(t *testing.T, lines ...string) string {
+	path := filepath.Join(t.TempDir(), "snapshot.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+	return path
+}
+
+func TestImportByID(t *testing.T) {
+	server, store := newEntityServer(t, applyEntities)
+
+	snapshot := writeSnapshot(t,
+		`{"id":"e-1","type":"Host","name":"web-01","tags":{"env":"production"}}`,
+		`{"id":"e-3","type":"Service","name":"checkout","tags":{"team":"payments"}}`,
+		`{"id":"e-404","type":"Host","name":"gone","tags":{"env":"production"}}`,
+	)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: snapshot, Match: MatchByID, AutoApprove: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Import(context.Background()))
+	require.Equal(t, 1, store.putCount())
+
+	web01 := store.get("e-1")
+	require.Len(t, web01.Tags, 1)
+	require.Equal(t, "production", *web01.Tags["env"])
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "Skipping e-404 (Host gone)")
+	require.Contains(t, output, "Plan: 1 of 2 entities to update")
+}
+
+func TestImportByName(t *testing.T) {
+	recreated := []Entity{
+		{ID: "e-new-1", Type: "Host", Name: "web-01", Tags: map[string]*string{"env": stringPtr("staging")}},
+		{ID: "e-new-2", Type: "Host", Name: "web-02"},
+		{ID: "e-new-3", Type: "Host", Name: "web-02"},
+	}
+	server, store := newEntityServer(t, recreated)
+
+	snapshot := writeSnapshot(t,
+		`{"id":"e-1","type":"Host","name":"web-01","tags":{"team":"backend"}}`,
+		`{"id":"e-2","type":"Host","name":"web-02","tags":{"team":"backend"}}`,
+		`{"id":"e-3","type":"Host","tags":{"team":"backend"}}`,
+	)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: snapshot, Match: MatchByName, Merge: true, AutoApprove: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Import(context.Background()))
+	require.Equal(t, 1, store.putCount())
+
+	web01 := store.get("e-new-1")
+	require.Len(t, web01.Tags, 2)
+	require.Equal(t, "backend", *web01.Tags["team"])
+	require.Equal(t, "staging", *web01.Tags["env"])
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "Skipping e-2 (Host web-02): more than one entity matches")
+	require.Contains(t, output, "Skipping e-3 (Host ): snapshot entity has no name")
+
+	// Entities of a type are listed once for all snapshot entities
+	require.Equal(t, 1, store.lists)
+}
+
+func TestImportJSONReportsSkipped(t *testing.T) {
+	server, _ := newEntityServer(t, applyEntities)
+
+	snapshot := writeSnapshot(t,
+		`{"id":"e-1","type":"Host","name":"web-01","tags":{"env":"production"}}`,
+		`{"id":"e-404","type":"Host","name":"gone","tags":{"env":"production"}}`,
+	)
+
+	client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: snapshot, Match: MatchByID, AutoApprove: true, JSON: true})
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.Import(context.Background()))
+
+	var response applyResponse
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &response))
+	require.Len(t, response.Plan, 1)
+	require.Len(t, response.Skipped, 1)
+	require.Equal(t, "e-404", response.Skipped[0].ID)
+	require.Contains(t, response.Skipped[0].Error, "404")
+}
+
+func TestImportFailsOnAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	snapshot := writeSnapshot(t, `{"id":"e-1","type":"Host","name":"web-01","tags":{"env":"production"}}`)
+
+	for _, match := range []string{MatchByID, MatchByName} {
+		client, err := NewClient(&Options{BaseOptions: shared.BaseOptions{Token: "test-token", APIURL: server.URL}, File: snapshot, Match: match, AutoApprove: true})
+		require.NoError(t, err)
+		client.output = testutil.TempFile(t)
+		client.input = testutil.TempFile(t)
+		client.prompts = testutil.TempFile(t)
+		err = client.Import(context.Background())
+		require.ErrorIs(t, err, ErrInvalidAPIResponse)
+		require.NotContains(t, testutil.ReadOutput(t, client.output), "Skipping")
+	}
+}
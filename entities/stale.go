@@ -0,0 +1,217 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultConcurrency is how many stale entities are deleted or tagged at the same time
+	DefaultConcurrency = 4
+
+	staleActionDelete = "delete"
+	staleActionTag    = "tag"
+)
+
+var errStaleActionFailed = errors.New("failed to change some stale entities")
+
+type staleResult struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	Name         string `json:"name,omitempty"`
+	LastSeenTime string `json:"lastSeenTime"`
+	Action       string `json:"action,omitempty"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+// staleEntities lists the entities of the selected type not seen within the threshold.
+// Entities without a last seen time are only included with --include-never-seen.
+func (c *Client) staleEntities(ctx context.Context, now time.Time) ([]Entity, error) {
+	var result []Entity
+	err := c.listEntities(ctx, c.opts.Type, c.opts.Name, func(entities []Entity) error {
+		for i := range entities {
+			if isStale(&entities[i], c.opts.StaleAfter, now) || (c.opts.IncludeNeverSeen && neverSeen(&entities[i])) {
+				result = append(result, entities[i])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) staleAction() string {
+	switch {
+	case c.opts.Delete:
+		return staleActionDelete
+	case len(c.opts.Tags) > 0:
+		return staleActionTag
+	default:
+		return ""
+	}
+}
+
+// processStale deletes or tags a single stale entity
+func (c *Client) processStale(ctx context.Context, entity Entity, action string) staleResult {
+	result := staleResult{ID: entity.ID, Type: entity.Type, Name: entity.Name, LastSeenTime: entity.LastSeenTime, Action: action}
+
+	var err error
+	switch action {
+	case staleActionDelete:
+		err = c.deleteEntity(ctx, entity.ID)
+	default:
+		desired := entity
+		desired.Tags = c.opts.computeTags(entity.Tags)
+		if len(diffTags(entity.Tags, desired.Tags)) == 0 {
+			result.Status = "unchanged"
+			return result
+		}
+		err = c.putEntity(ctx, &desired)
+	}
+
+	switch {
+	case err != nil:
+		result.Status = "failed"
+		result.Error = err.Error()
+	case c.opts.DryRun:
+		result.Status = "dry-run"
+	default:
+		result.Status = "success"
+	}
+	return result
+}
+
+// processAllStale runs the action on all entities, at most concurrency at a time.
+// Results keep the order of the entities.
+func (c *Client) processAllStale(ctx context.Context, entities []Entity, action string, concurrency int) []staleResult {
+	results := make([]staleResult, len(entities))
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i := range entities {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = c.processStale(ctx, entities[i], action)
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func lastSeen(entity *Entity) string {
+	if entity.LastSeenTime == "" {
+		return "never"
+	}
+	return entity.LastSeenTime
+}
+
+func (c *Client) printStalePlan(entities []Entity, action string) {
+	_, _ = fmt.Fprintf(c.output, "%d entities not seen for more than %s:\n", len(entities), shared.FormatDuration(c.opts.StaleAfter))
+	for i := range entities {
+		_, _ = fmt.Fprintf(c.output, "  %s, last seen %s\n", describeEntity(&entities[i]), lastSeen(&entities[i]))
+	}
+
+	if action == staleActionDelete {
+		_, _ = fmt.Fprintf(c.output, "Plan: delete %d entities\n", len(entities))
+		return
+	}
+
+	tags := make([]string, 0, len(c.opts.Tags))
+	for key, value := range c.opts.Tags {
+		tags = append(tags, key+"="+value)
+	}
+	sort.Strings(tags)
+	_, _ = fmt.Fprintf(c.output, "Plan: tag %d entities with %s\n", len(entities), strings.Join(tags, ", "))
+}
+
+func (c *Client) printStaleResult(result staleResult) error {
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	done, verb := "tagged", "tag"
+	if result.Action == staleActionDelete {
+		done, verb = "deleted", "delete"
+	}
+
+	switch result.Status {
+	case "success":
+		_, _ = fmt.Fprintf(c.output, "Entity %s %s\n", result.ID, done)
+	case "dry-run":
+		_, _ = fmt.Fprintf(c.output, "Dry run: entity %s was not %s\n", result.ID, done)
+	case "unchanged":
+		_, _ = fmt.Fprintf(c.output, "Entity %s already %s\n", result.ID, done)
+	default:
+		_, _ = fmt.Fprintf(c.output, "Failed to %s entity %s: %s\n", verb, result.ID, result.Error)
+	}
+	return nil
+}
+
+// Stale lists the entities not seen within the threshold and optionally deletes
+// or tags them after confirmation
+func (c *Client) Stale(ctx context.Context) error {
+	entities, err := c.staleEntities(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	action := c.staleAction()
+	if action == "" {
+		return c.printEntities(entities)
+	}
+
+	if !c.opts.JSON {
+		c.printStalePlan(entities, action)
+	}
+	if len(entities) == 0 {
+		return nil
+	}
+
+	if !c.opts.AutoApprove && !c.opts.DryRun {
+		if err := shared.Approve(c.input, c.prompts, fmt.Sprintf("Do you want to %s these entities?", action)); err != nil {
+			return err
+		}
+	}
+
+	// Dry run requests are printed while they are made, so they must not interleave
+	concurrency := c.opts.Concurrency
+	if c.opts.DryRun {
+		concurrency = 1
+	}
+
+	failed := 0
+	for _, result := range c.processAllStale(ctx, entities, action, concurrency) {
+		if result.Status == "failed" {
+			failed++
+		}
+		if err := c.printStaleResult(result); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errStaleActionFailed, failed, len(entities))
+	}
+	return nil
+}
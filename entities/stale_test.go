@@ -0,0 +1,187 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func staleEntities() []Entity {
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-10 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	return []Entity{
+		{ID: "e-1", Type: "Host", Name: "web-01", LastSeenTime: recent, Tags: map[string]*string{}},
+		{ID: "e-2", Type: "Host", Name: "web-02", LastSeenTime: old, Tags: map[string]*string{}},
+		{ID: "e-3", Type: "Host", Name: "web-03", Tags: map[string]*string{"stale": stringPtr("true")}},
+		{ID: "e-4", Type: "Service", Name: "api", LastSeenTime: old, Tags: map[string]*string{}},
+	}
+}
+
+func newStaleOptions() *Options {
+	opts := NewOptions()
+	opts.Type = "Host"
+	opts.StaleAfter = 7 * 24 * time.Hour
+	opts.IncludeNeverSeen = true
+	opts.Concurrency = DefaultConcurrency
+	opts.AutoApprove = true
+	return opts
+}
+
+func TestStaleList(t *testing.T) {
+	server, store := newEntityServer(t, staleEntities())
+
+	opts := newStaleOptions()
+	opts.AutoApprove = false
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.Stale(context.Background()))
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "ID: e-2")
+	require.Contains(t, output, "ID: e-3")
+	require.NotContains(t, output, "ID: e-1")
+	require.NotContains(t, output, "ID: e-4")
+	require.Equal(t, 0, store.putCount())
+
+	// Entities never seen are only stale with --include-never-seen
+	opts = newStaleOptions()
+	opts.AutoApprove = false
+	opts.IncludeNeverSeen = false
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err = NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.Stale(context.Background()))
+	output = testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "ID: e-2")
+	require.NotContains(t, output, "ID: e-3")
+}
+
+func TestStaleDelete(t *testing.T) {
+	server, store := newEntityServer(t, staleEntities())
+
+	opts := newStaleOptions()
+	opts.Delete = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.Stale(context.Background()))
+	sort.Strings(store.deletes)
+	require.Equal(t, []string{"e-2", "e-3"}, store.deletes)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "Plan: delete 2 entities")
+	require.Contains(t, output, "Host web-03 (e-3), last seen never")
+	require.Contains(t, output, "Entity e-2 deleted")
+}
+
+func TestStaleDeleteRequiresApproval(t *testing.T) {
+	server, store := newEntityServer(t, staleEntities())
+
+	opts := newStaleOptions()
+	opts.Delete = true
+	opts.AutoApprove = false
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.ErrorIs(t, client.Stale(context.Background()), shared.ErrNotApproved)
+	require.Empty(t, store.deletes)
+	require.NotContains(t, testutil.ReadOutput(t, client.output), "use --auto-approve")
+}
+
+func TestStaleDeleteDryRun(t *testing.T) {
+	server, store := newEntityServer(t, staleEntities())
+
+	opts := newStaleOptions()
+	opts.Delete = true
+	opts.AutoApprove = false
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.Stale(context.Background()))
+	require.Empty(t, store.deletes)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, fmt.Sprintf("DELETE %s/v1/entities/e-2", server.URL))
+	require.Contains(t, output, "Dry run: entity e-3 was not deleted")
+}
+
+func TestStaleTag(t *testing.T) {
+	server, store := newEntityServer(t, staleEntities())
+
+	opts := newStaleOptions()
+	opts.Tags = map[string]string{"stale": "true"}
+	opts.Concurrency = 1
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.Stale(context.Background()))
+	require.Equal(t, 1, store.putCount())
+	require.Equal(t, "true", *store.get("e-2").Tags["stale"])
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "Plan: tag 2 entities with stale=true")
+	require.Contains(t, output, "Entity e-2 tagged")
+	require.Contains(t, output, "Entity e-3 already tagged")
+}
+
+func TestStaleDeleteFailure(t *testing.T) {
+	server, store := newEntityServer(t, staleEntities())
+
+	opts := newStaleOptions()
+	opts.Delete = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	// e-3 disappears between listing and deleting
+	entities, err := client.staleEntities(context.Background(), time.Now())
+	require.NoError(t, err)
+	delete(store.entities, "e-3")
+
+	results := client.processAllStale(context.Background(), entities, staleActionDelete, 2)
+	require.Equal(t, "success", results[0].Status)
+	require.Equal(t, "failed", results[1].Status)
+	require.Contains(t, results[1].Error, "404")
+}
@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+// DefaultStaleAfter is how long an entity may go unseen before it is considered stale
+const DefaultStaleAfter = 24 * time.Hour
+
+// TypeSummary counts the entities of a single type
+type TypeSummary struct {
+	Type          string `json:"type"`
+	Total         int    `json:"total"`
+	InMaintenance int    `json:"inMaintenance"`
+	Stale         int    `json:"stale"`
+}
+
+// InventorySummary counts the entities of all types
+type InventorySummary struct {
+	StaleAfter string        `json:"staleAfter"`
+	Types      []TypeSummary `json:"types"`
+	Total      TypeSummary   `json:"total"`
+}
+
+// isStale reports whether an entity was not seen since the threshold. Entities
+// without a valid LastSeenTime are not known to be stale, so they are not.
+func isStale(entity *Entity, threshold time.Duration, now time.Time) bool {
+	lastSeen, err := time.Parse(time.RFC3339, entity.LastSeenTime)
+	if err != nil {
+		return false
+	}
+	return now.Sub(lastSeen) > threshold
+}
+
+// neverSeen reports whether an entity has no valid LastSeenTime
+func neverSeen(entity *Entity) bool {
+	_, err := time.Parse(time.RFC3339, entity.LastSeenTime)
+	return err != nil
+}
+
+// summarize counts the entities of the given types
+func (c *Client) summarize(ctx context.Context, types []string, now time.Time) (*InventorySummary, error) {
+	summary := &InventorySummary{StaleAfter: shared.FormatDuration(c.opts.StaleAfter), Types: []TypeSummary{}, Total: TypeSummary{Type: "total"}}
+
+	for _, t := range types {
+		typeSummary := TypeSummary{Type: t}
+		err := c.listEntities(ctx, t, "", func(entities []Entity) error {
+			for i := range entities {
+				typeSummary.Total++
+				if entities[i].InMaintenance {
+					typeSummary.InMaintenance++
+				}
+				if isStale(&entities[i], c.opts.StaleAfter, now) {
+					typeSummary.Stale++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list entities of type %s: %w", t, err)
+		}
+
+		summary.Types = append(summary.Types, typeSummary)
+		summary.Total.Total += typeSummary.Total
+		summary.Total.InMaintenance += typeSummary.InMaintenance
+		summary.Total.Stale += typeSummary.Stale
+	}
+
+	return summary, nil
+}
+
+func (c *Client) printSummary(summary *InventorySummary) error {
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(c.output, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "TYPE\tTOTAL\tIN MAINTENANCE\tSTALE (>%s)\n", summary.StaleAfter)
+	for _, s := range append(summary.Types, summary.Total) {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", s.Type, s.Total, s.InMaintenance, s.Stale)
+	}
+	return w.Flush()
+}
+
+// Summary prints entity counts per type, with the number of entities in
+// maintenance and of entities not seen within the staleness threshold
+func (c *Client) Summary(ctx context.Context) error {
+	types := []string{c.opts.Type}
+	if c.opts.Type == "" {
+		var err error
+		types, err = c.fetchTypes(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	summary, err := c.summarize(ctx, types, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return c.printSummary(summary)
+}
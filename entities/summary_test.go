@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStale(t *testing.T) {
+	now := time.Date(2024, 5, 13, 12, 0, 0, 0, time.UTC)
+
+	require.False(t, isStale(&Entity{LastSeenTime: "2024-05-13T11:00:00Z"}, time.Hour*2, now))
+	require.True(t, isStale(&Entity{LastSeenTime: "2024-05-13T09:00:00Z"}, time.Hour*2, now))
+	require.False(t, isStale(&Entity{}, time.Hour*2, now))
+	require.False(t, isStale(&Entity{LastSeenTime: "yesterday"}, time.Hour*2, now))
+	require.True(t, neverSeen(&Entity{LastSeenTime: "yesterday"}))
+}
+
+func TestSummary(t *testing.T) {
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-72 * time.Hour).UTC().Format(time.RFC3339)
+
+	server, _ := newEntityServer(t, []Entity{
+		{ID: "e-1", Type: "Host", LastSeenTime: recent},
+		{ID: "e-2", Type: "Host", LastSeenTime: old, InMaintenance: true},
+		{ID: "e-3", Type: "Host", LastSeenTime: recent, InMaintenance: true},
+		{ID: "e-4", Type: "Service", LastSeenTime: old},
+	})
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.StaleAfter = DefaultStaleAfter
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.Summary(context.Background()))
+
+	var summary InventorySummary
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &summary))
+	require.Equal(t, InventorySummary{
+		StaleAfter: "1d",
+		Types: []TypeSummary{
+			{Type: "Host", Total: 3, InMaintenance: 2, Stale: 1},
+			{Type: "Service", Total: 1, InMaintenance: 0, Stale: 1},
+		},
+		Total: TypeSummary{Type: "total", Total: 4, InMaintenance: 2, Stale: 2},
+	}, summary)
+}
+
+func TestSummaryTable(t *testing.T) {
+	old := time.Now().Add(-10 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	server, _ := newEntityServer(t, []Entity{{ID: "e-1", Type: "Host", LastSeenTime: old}})
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.Type = "Host"
+	opts.StaleAfter = 7 * 24 * time.Hour
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.Summary(context.Background()))
+	require.Equal(t, "TYPE   TOTAL  IN MAINTENANCE  STALE (>1w)\n"+
+		"Host   1      0               1\n"+
+		"total  1      0               1\n", testutil.ReadOutput(t, client.output))
+}
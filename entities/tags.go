@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	tagChangeAdd    = "add"
+	tagChangeUpdate = "update"
+	tagChangeRemove = "remove"
+)
+
+// TagChange describes a single difference between two tag sets
+type TagChange struct {
+	Action   string `json:"action"`
+	Key      string `json:"key"`
+	OldValue string `json:"oldValue,omitempty"`
+	NewValue string `json:"newValue,omitempty"`
+}
+
+// loadTagFile reads a YAML file containing a flat key: value map of tags
+func loadTagFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tag file %s: %w", path, err)
+	}
+
+	tags := make(map[string]string)
+	if err := yaml.Unmarshal(content, &tags); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling %s tag file: %w", path, err)
+	}
+
+	return tags, nil
+}
+
+func tagValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// computeTags returns the final tag set of an entity after applying the tag options.
+// Tags are merged into the current set unless ReplaceTags is set, and RemoveTags
+// are deleted afterwards.
+func (o *Options) computeTags(current map[string]*string) map[string]*string {
+	result := make(map[string]*string)
+	if !o.ReplaceTags {
+		for key, value := range current {
+			result[key] = value
+		}
+	}
+
+	for key, value := range o.Tags {
+		result[key] = &value
+	}
+
+	for _, key := range o.RemoveTags {
+		delete(result, key)
+	}
+
+	return result
+}
+
+// diffTags lists the changes needed to turn the before tag set into the after tag set, sorted by key
+func diffTags(before, after map[string]*string) []TagChange {
+	var changes []TagChange
+
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		if !ok {
+			changes = append(changes, TagChange{Action: tagChangeRemove, Key: key, OldValue: tagValue(oldValue)})
+		} else if tagValue(oldValue) != tagValue(newValue) {
+			changes = append(changes, TagChange{Action: tagChangeUpdate, Key: key, OldValue: tagValue(oldValue), NewValue: tagValue(newValue)})
+		}
+	}
+
+	for key, newValue := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, TagChange{Action: tagChangeAdd, Key: key, NewValue: tagValue(newValue)})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+func printTagChanges(w io.Writer, changes []TagChange) {
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintln(w, "No tag changes")
+		return
+	}
+
+	for _, change := range changes {
+		switch change.Action {
+		case tagChangeAdd:
+			_, _ = fmt.Fprintf(w, "+ %s=%s\n", change.Key, change.NewValue)
+		case tagChangeUpdate:
+			_, _ = fmt.Fprintf(w, "~ %s=%s -> %s\n", change.Key, change.OldValue, change.NewValue)
+		case tagChangeRemove:
+			_, _ = fmt.Fprintf(w, "- %s=%s\n", change.Key, change.OldValue)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTags(t *testing.T) {
+	current := map[string]*string{
+		"env":  stringPtr("production"),
+		"team": stringPtr("backend"),
+		"old":  stringPtr("value"),
+	}
+
+	testCases := []struct {
+		name     string
+		opts     *Options
+		expected map[string]string
+	}{
+		{
+			name: "merge new tags",
+			opts: &Options{Tags: map[string]string{"env": "staging", "new": "tag"}},
+			expected: map[string]string{
+				"env":  "staging",
+				"team": "backend",
+				"old":  "value",
+				"new":  "tag",
+			},
+		},
+		{
+			name: "remove tags",
+			opts: &Options{RemoveTags: []string{"old", "missing"}},
+			expected: map[string]string{
+				"env":  "production",
+				"team": "backend",
+			},
+		},
+		{
+			name: "replace tags",
+			opts: &Options{Tags: map[string]string{"env": "staging"}, ReplaceTags: true},
+			expected: map[string]string{
+				"env": "staging",
+			},
+		},
+		{
+			name:     "replace with empty set clears tags",
+			opts:     &Options{ReplaceTags: true},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.opts.computeTags(current)
+
+			actual := make(map[string]string)
+			for key, value := range result {
+				actual[key] = tagValue(value)
+			}
+			require.Equal(t, tc.expected, actual)
+			require.Len(t, current, 3, "current tags must not be modified")
+		})
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	before := map[string]*string{
+		"env":  stringPtr("production"),
+		"team": stringPtr("backend"),
+		"old":  stringPtr("value"),
+	}
+	after := map[string]*string{
+		"env":  stringPtr("staging"),
+		"team": stringPtr("backend"),
+		"new":  stringPtr("tag"),
+	}
+
+	changes := diffTags(before, after)
+	require.Equal(t, []TagChange{
+		{Action: tagChangeUpdate, Key: "env", OldValue: "production", NewValue: "staging"},
+		{Action: tagChangeAdd, Key: "new", NewValue: "tag"},
+		{Action: tagChangeRemove, Key: "old", OldValue: "value"},
+	}, changes)
+
+	require.Empty(t, diffTags(before, before))
+}
+
+func TestLoadTagFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("env: production\nport: 8080\n"), 0o600))
+
+	opts := NewOptions()
+	require.NoError(t, opts.ParseTags([]string{"env=staging"}))
+	require.NoError(t, opts.LoadTagFile(path))
+
+	// Tags given on the command line win over the file
+	require.Equal(t, map[string]string{"env": "staging", "port": "8080"}, opts.Tags)
+
+	require.Error(t, opts.LoadTagFile(filepath.Join(t.TempDir(), "missing.yaml")))
+
+	invalid := filepath.Join(t.TempDir(), "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("- not\n- a map\n"), 0o600))
+	require.Error(t, opts.LoadTagFile(invalid))
+}
@@ -0,0 +1,303 @@
+// Package events provides a client for creating and listing change events in the SWO API.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultPageSize for retrieving list of events
+	DefaultPageSize = 100
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+)
+
+// Client is an events client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	output     *os.File
+}
+
+// Event is a change event, like a deploy or a configuration change. Name is the
+// type of the event that groups events of the same kind.
+type Event struct {
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	Timestamp   int64             `json:"timestamp"`
+	EntityIDs   []string          `json:"entityIds,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type listEventsResponse struct {
+	Events   []Event `json:"changeEvents"`
+	pageInfo `json:"pageInfo"`
+}
+
+type createEventResponse struct {
+	ID string `json:"id"`
+}
+
+// NewClient creates a new events client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		output:     os.Stdout,
+	}, nil
+}
+
+func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+	if c.opts.Type != "" {
+		params.Add("name", c.opts.Type)
+	}
+	if c.opts.MinTime != "" {
+		params.Add("startTime", c.opts.MinTime)
+	}
+	if c.opts.MaxTime != "" {
+		params.Add("endTime", c.opts.MaxTime)
+	}
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/changeevents")
+}
+
+func (c *Client) prepareCreateRequest(ctx context.Context, event *Event) (*http.Request, error) {
+	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/changeevents")
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Accept", "application/json")
+
+	return request, nil
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+func (c *Client) getEvents(ctx context.Context, nextPage string) (*listEventsResponse, error) {
+	request, err := c.prepareListRequest(ctx, nextPage)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response listEventsResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &response, nil
+}
+
+// postEvent creates the event and returns its ID. In dry run mode the request is
+// printed instead and the returned ID is empty.
+func (c *Client) postEvent(ctx context.Context, event *Event) (string, error) {
+	request, err := c.prepareCreateRequest(ctx, event)
+	if err != nil {
+		return "", fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	if c.opts.DryRun {
+		return "", shared.PrintDryRunRequest(c.output, request)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return "", err
+	}
+
+	var response createEventResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return "", fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return response.ID, nil
+}
+
+// newEvent builds an event of the configured type, entities and tags, with the
+// extra tags added on top
+func (c *Client) newEvent(title string, description string, at time.Time, extraTags map[string]string) *Event {
+	event := &Event{
+		Name:        c.opts.Type,
+		Title:       title,
+		Description: description,
+		Timestamp:   at.Unix(),
+		EntityIDs:   c.opts.Entities,
+	}
+
+	if len(c.opts.Tags) > 0 || len(extraTags) > 0 {
+		event.Tags = make(map[string]string, len(c.opts.Tags)+len(extraTags))
+		for key, value := range c.opts.Tags {
+			event.Tags[key] = value
+		}
+		for key, value := range extraTags {
+			event.Tags[key] = value
+		}
+	}
+
+	return event
+}
+
+func formatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key + "=" + tags[key]
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (c *Client) printEvents(events []Event) error {
+	for _, event := range events {
+		if c.opts.JSON {
+			jsonData, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(c.output, string(jsonData))
+			continue
+		}
+
+		_, _ = fmt.Fprintf(c.output, "ID: %s, Time: %s, Type: %s, Title: %s", event.ID,
+			time.Unix(event.Timestamp, 0).UTC().Format(time.RFC3339), event.Name, event.Title)
+		if event.Description != "" {
+			_, _ = fmt.Fprintf(c.output, ", Description: %s", event.Description)
+		}
+		if len(event.EntityIDs) > 0 {
+			_, _ = fmt.Fprintf(c.output, ", Entities: %s", strings.Join(event.EntityIDs, ", "))
+		}
+		if len(event.Tags) > 0 {
+			_, _ = fmt.Fprintf(c.output, ", Tags: %s", formatTags(event.Tags))
+		}
+		_, _ = fmt.Fprintln(c.output)
+	}
+	return nil
+}
+
+// CreateEvent creates a change event from the options
+func (c *Client) CreateEvent(ctx context.Context) error {
+	event := c.newEvent(c.opts.Title, c.opts.Description, time.Now(), nil)
+
+	id, err := c.postEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+	if c.opts.DryRun {
+		return nil
+	}
+
+	if c.opts.JSON {
+		event.ID = id
+		return c.printEvents([]Event{*event})
+	}
+	_, _ = fmt.Fprintf(c.output, "Event %s created\n", id)
+	return nil
+}
+
+// ListEvents retrieves and displays change events, optionally filtered by type and time
+func (c *Client) ListEvents(ctx context.Context) error {
+	var nextPage string
+
+	for {
+		response, err := c.getEvents(ctx, nextPage)
+		if err != nil {
+			return err
+		}
+
+		if err := c.printEvents(response.Events); err != nil {
+			return fmt.Errorf("failed to print result: %w", err)
+		}
+
+		if response.NextPage == "" {
+			break
+		}
+		nextPage = response.NextPage
+	}
+
+	return nil
+}
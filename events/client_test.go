@@ -0,0 +1,150 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// eventStore records created events and serves them in pages of two
+type eventStore struct {
+	mu      sync.Mutex
+	created []Event
+	queries []string
+}
+
+func newEventServer(t *testing.T, events []Event) (*httptest.Server, *eventStore) {
+	store := &eventStore{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/changeevents", r.URL.Path)
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		switch r.Method {
+		case "POST":
+			var event Event
+			if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+				t.Errorf("Failed to decode event: %v", err)
+			}
+			store.created = append(store.created, event)
+			testutil.WriteJSON(t, w, createEventResponse{ID: "ev-" + strconv.Itoa(len(store.created))})
+		case "GET":
+			store.queries = append(store.queries, r.URL.RawQuery)
+			response := listEventsResponse{Events: events}
+			if r.URL.Query().Get("page") == "" && len(events) > 2 {
+				response.Events = events[:2]
+				response.NextPage = "/v1/changeevents?page=2"
+			} else if len(events) > 2 {
+				response.Events = events[2:]
+			}
+			testutil.WriteJSON(t, w, response)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, store
+}
+
+func TestCreateEvent(t *testing.T) {
+	server, store := newEventServer(t, nil)
+
+	opts := NewOptions()
+	opts.Title = "Deployed v1.2.3"
+	opts.Description = "Rolled out to all regions"
+	opts.Type = "deploy"
+	opts.Entities = []string{"e-1", "e-2"}
+	opts.Tags = map[string]string{"env": "prod"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	before := time.Now().Unix()
+	require.NoError(t, client.CreateEvent(context.Background()))
+
+	require.Len(t, store.created, 1)
+	event := store.created[0]
+	require.Equal(t, "deploy", event.Name)
+	require.Equal(t, "Deployed v1.2.3", event.Title)
+	require.Equal(t, "Rolled out to all regions", event.Description)
+	require.Equal(t, []string{"e-1", "e-2"}, event.EntityIDs)
+	require.Equal(t, map[string]string{"env": "prod"}, event.Tags)
+	require.GreaterOrEqual(t, event.Timestamp, before)
+
+	require.Equal(t, "Event ev-1 created\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestCreateEventDryRun(t *testing.T) {
+	server, store := newEventServer(t, nil)
+
+	opts := NewOptions()
+	opts.Title = "Deployed v1.2.3"
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.CreateEvent(context.Background()))
+	require.Empty(t, store.created)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.True(t, strings.HasPrefix(output, "POST "+server.URL+"/v1/changeevents\n"))
+	require.Contains(t, output, `"title": "Deployed v1.2.3"`)
+}
+
+func TestListEvents(t *testing.T) {
+	events := []Event{
+		{ID: "ev-1", Name: "deploy", Title: "Deployed v1", Timestamp: 1715594400, EntityIDs: []string{"e-1"}, Tags: map[string]string{"env": "prod", "app": "api"}},
+		{ID: "ev-2", Name: "deploy", Title: "Deployed v2", Timestamp: 1715598000},
+		{ID: "ev-3", Name: "deploy", Title: "Deployed v3", Description: "Hotfix", Timestamp: 1715601600},
+	}
+	server, store := newEventServer(t, events)
+
+	opts := NewOptions()
+	opts.Type = "deploy"
+	opts.MinTime = "2024-05-13T00:00:00Z"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.ListEvents(context.Background()))
+	require.Equal(t, []string{"name=deploy&pageSize=100&startTime=2024-05-13T00%3A00%3A00Z", "page=2"}, store.queries)
+	require.Equal(t, `ID: ev-1, Time: 2024-05-13T10:00:00Z, Type: deploy, Title: Deployed v1, Entities: e-1, Tags: app=api, env=prod
+ID: ev-2, Time: 2024-05-13T11:00:00Z, Type: deploy, Title: Deployed v2
+ID: ev-3, Time: 2024-05-13T12:00:00Z, Type: deploy, Title: Deployed v3, Description: Hotfix
+`, testutil.ReadOutput(t, client.output))
+}
+
+func TestListEventsJSON(t *testing.T) {
+	events := []Event{{ID: "ev-1", Name: "deploy", Title: "Deployed v1", Timestamp: 1715594400}}
+	server, _ := newEventServer(t, events)
+
+	opts := NewOptions()
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.ListEvents(context.Background()))
+	require.Equal(t, `{"id":"ev-1","name":"deploy","title":"Deployed v1","timestamp":1715594400}`+"\n", testutil.ReadOutput(t, client.output))
+}
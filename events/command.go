@@ -0,0 +1,99 @@
+package events
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+func eventFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "Type of the event, e.g. deploy or config-change",
+			Value: DefaultType,
+		},
+		&cli.StringFlag{
+			Name:  "description",
+			Usage: "Description of the event",
+		},
+		&cli.StringSliceFlag{
+			Name:  "entity",
+			Usage: "ID of an entity the event relates to (can be specified multiple times)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Tag in key=value format (can be specified multiple times)",
+		},
+	}
+}
+
+// NewEventsCommand creates the events command
+func NewEventsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "events",
+		Usage: "Record and list change events like deploys and configuration changes",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "create",
+				Usage:  "Create a change event",
+				Action: runCreate,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:     "title",
+						Usage:    "Title of the event",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				}, eventFlags()...),
+			},
+			{
+				Name:   "list",
+				Usage:  "List change events",
+				Action: runList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Only list events of this type",
+					},
+					&cli.StringFlag{
+						Name:  "min-time",
+						Usage: "earliest time of the events",
+						Value: "1 day ago",
+					},
+					&cli.StringFlag{
+						Name:  "max-time",
+						Usage: "latest time of the events",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "wrap",
+				Usage:     "Run a command between a start and a finish event",
+				ArgsUsage: "-- COMMAND [ARGS...]",
+				Description: `Posts a start event, runs the command and posts a finish event with its exit
+status and duration, e.g.
+
+   swo events wrap --type deploy --entity e-123 -- ./deploy.sh v1.2.3
+
+The title defaults to the command line. swo exits with the exit code of the
+command. Failing to post an event is logged but does not fail the command.`,
+				Action: runWrap,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "title",
+						Usage: "Title of the events, defaults to the command line",
+					},
+				}, eventFlags()...),
+			},
+		},
+	}
+}
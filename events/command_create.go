@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+// setEventOptions sets the options shared by create and wrap
+func setEventOptions(ctx *cli.Context, opts *Options) error {
+	opts.Title = ctx.String("title")
+	opts.Description = ctx.String("description")
+	opts.Type = ctx.String("type")
+	opts.Entities = ctx.StringSlice("entity")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	return opts.ParseTags(ctx.StringSlice("tag"))
+}
+
+func runCreate(ctx *cli.Context) error {
+	opts := NewOptions()
+	if err := setEventOptions(ctx, opts); err != nil {
+		return err
+	}
+	opts.JSON = ctx.Bool("json")
+
+	if err := opts.ValidateForCreate(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.CreateEvent(context.Background())
+}
@@ -0,0 +1,34 @@
+package events
+
+import (
+	"context"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+func runWrap(ctx *cli.Context) error {
+	opts := NewOptions()
+	if err := setEventOptions(ctx, opts); err != nil {
+		return err
+	}
+	opts.Command = ctx.Args().Slice()
+
+	if err := opts.ValidateForWrap(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	code, err := client.Wrap(context.Background())
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		// Exit with the status of the wrapped command without printing anything
+		return cli.Exit("", code)
+	}
+	return nil
+}
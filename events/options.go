@@ -0,0 +1,92 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultType is the type of events created without --type
+	DefaultType = "change"
+)
+
+var (
+	errMissingTitle   = errors.New("event title is required")
+	errMissingType    = errors.New("event type is required")
+	errMissingCommand = errors.New("command is required, e.g. swo events wrap -- make deploy")
+	errInvalidTag     = errors.New("invalid tag format, expected key=value")
+)
+
+// Options represents the command line options for the events command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	Title              string
+	Description        string
+	Type               string
+	Entities           []string
+	Tags               map[string]string
+	MinTime            string
+	MaxTime            string
+	Command            []string
+	JSON               bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{
+		Type: DefaultType,
+		Tags: make(map[string]string),
+	}
+}
+
+// ParseTags parses tag strings in key=value format into a map
+func (o *Options) ParseTags(tagStrings []string) error {
+	o.Tags = make(map[string]string, len(tagStrings))
+	for _, tagStr := range tagStrings {
+		key, value, ok := strings.Cut(tagStr, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("%w: %s", errInvalidTag, tagStr)
+		}
+		o.Tags[key] = strings.TrimSpace(value)
+	}
+	return nil
+}
+
+// Init parses the time flags into RFC3339 timestamps relative to now
+func (o *Options) Init(now time.Time) error {
+	minTime, maxTime, err := shared.ParseTimeRange(o.MinTime, o.MaxTime, now)
+	if err != nil {
+		return err
+	}
+
+	o.MinTime, o.MaxTime = minTime, maxTime
+	return nil
+}
+
+// ValidateForCreate validates options for create operation
+func (o *Options) ValidateForCreate() error {
+	if strings.TrimSpace(o.Title) == "" {
+		return errMissingTitle
+	}
+	if strings.TrimSpace(o.Type) == "" {
+		return errMissingType
+	}
+	return nil
+}
+
+// ValidateForWrap validates options for wrap operation. The title defaults to
+// the wrapped command line.
+func (o *Options) ValidateForWrap() error {
+	if len(o.Command) == 0 {
+		return errMissingCommand
+	}
+	if strings.TrimSpace(o.Type) == "" {
+		return errMissingType
+	}
+	return nil
+}
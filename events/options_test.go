@@ -0,0 +1,57 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTags(t *testing.T) {
+	opts := NewOptions()
+	require.NoError(t, opts.ParseTags([]string{"env=prod", " app = api "}))
+	require.Equal(t, map[string]string{"env": "prod", "app": "api"}, opts.Tags)
+
+	require.ErrorIs(t, opts.ParseTags([]string{"env"}), errInvalidTag)
+	require.ErrorIs(t, opts.ParseTags([]string{"=prod"}), errInvalidTag)
+}
+
+func TestInit(t *testing.T) {
+	now := time.Date(2024, 5, 13, 12, 0, 0, 0, time.UTC)
+
+	opts := NewOptions()
+	opts.MinTime = "2024-05-13T10:00:00Z"
+	opts.MaxTime = "2024-05-13 11:00:00 UTC"
+	require.NoError(t, opts.Init(now))
+	require.Equal(t, "2024-05-13T10:00:00Z", opts.MinTime)
+	require.Equal(t, "2024-05-13T11:00:00Z", opts.MaxTime)
+
+	opts = NewOptions()
+	opts.MinTime = "what?"
+	require.ErrorIs(t, opts.Init(now), shared.ErrMinTimeFlag)
+
+	opts = NewOptions()
+	opts.MaxTime = "what?"
+	require.ErrorIs(t, opts.Init(now), shared.ErrMaxTimeFlag)
+
+	opts = NewOptions()
+	opts.MinTime = "2024-05-13T11:00:00Z"
+	opts.MaxTime = "2024-05-13T10:00:00Z"
+	require.ErrorIs(t, opts.Init(now), shared.ErrInvalidTimeRange)
+}
+
+func TestValidate(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingTitle, opts.ValidateForCreate())
+	require.Equal(t, errMissingCommand, opts.ValidateForWrap())
+
+	opts.Title = "Deployed v1.2.3"
+	opts.Command = []string{"make", "deploy"}
+	require.NoError(t, opts.ValidateForCreate())
+	require.NoError(t, opts.ValidateForWrap())
+
+	opts.Type = " "
+	require.Equal(t, errMissingType, opts.ValidateForCreate())
+	require.Equal(t, errMissingType, opts.ValidateForWrap())
+}
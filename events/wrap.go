@@ -0,0 +1,135 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	phaseStart  = "start"
+	phaseFinish = "finish"
+
+	statusSuccess = "success"
+	statusFailure = "failure"
+)
+
+// runCommand runs the command with the standard streams of the CLI and returns
+// its exit code. Signals are caught before the command starts and forwarded to
+// it, so the finish event is still posted when the command is stopped.
+func (c *Client) runCommand(ctx context.Context, args []string) (int, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = c.output
+	cmd.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer func() {
+		signal.Stop(signals)
+		close(signals)
+	}()
+
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start command: %w", err)
+	}
+
+	go func() {
+		for sig := range signals {
+			// The terminal interrupts its whole foreground process group, so a
+			// command in the group of the CLI already got the interrupt
+			if sig == os.Interrupt && !ownProcessGroup(cmd.Process.Pid) {
+				continue
+			}
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	err := cmd.Wait()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0, nil
+	case errors.As(err, &exitErr):
+		if code := exitErr.ExitCode(); code > 0 {
+			return code, nil
+		}
+		// Terminated by a signal
+		return 1, nil
+	default:
+		return 0, err
+	}
+}
+
+// postWrapEvent posts a start or finish event. Failing to post an event only
+// logs a warning, the wrapped command must not fail because of its annotation.
+func (c *Client) postWrapEvent(ctx context.Context, event *Event) {
+	id, err := c.postEvent(ctx, event)
+	if err != nil {
+		slog.Warn("Failed to create event", "title", event.Title, "error", err)
+		return
+	}
+	if id != "" {
+		slog.Info("Event created", "id", id, "phase", event.Tags["phase"])
+	}
+}
+
+// Wrap runs the command between a start and a finish event. The finish event
+// carries the exit status and duration of the command. The exit code of the
+// command is returned. A dry run prints both events without running the command.
+func (c *Client) Wrap(ctx context.Context) (int, error) {
+	commandLine := strings.Join(c.opts.Command, " ")
+	title := c.opts.Title
+	if title == "" {
+		title = commandLine
+	}
+
+	start := time.Now()
+	c.postWrapEvent(ctx, c.newEvent("Started: "+title, c.opts.Description, start, map[string]string{
+		"phase":   phaseStart,
+		"command": commandLine,
+	}))
+
+	if c.opts.DryRun {
+		_, _ = fmt.Fprintf(c.output, "Dry run: command %q was not run\n", commandLine)
+		// The finish event is shown as it is posted after a successful run
+		c.postWrapEvent(ctx, c.finishEvent(title, commandLine, 0, 0, nil))
+		return 0, nil
+	}
+
+	code, runErr := c.runCommand(ctx, c.opts.Command)
+	duration := time.Since(start).Round(time.Millisecond)
+
+	c.postWrapEvent(context.WithoutCancel(ctx), c.finishEvent(title, commandLine, code, duration, runErr))
+
+	return code, runErr
+}
+
+// finishEvent builds the event posted after the command exited
+func (c *Client) finishEvent(title string, commandLine string, code int, duration time.Duration, runErr error) *Event {
+	status, prefix := statusSuccess, "Finished: "
+	if runErr != nil || code != 0 {
+		status, prefix = statusFailure, "Failed: "
+	}
+
+	description := fmt.Sprintf("Command %q exited with status %d after %s", commandLine, code, duration)
+	if runErr != nil {
+		description = fmt.Sprintf("Command %q failed after %s: %v", commandLine, duration, runErr)
+	}
+
+	return c.newEvent(prefix+title, description, time.Now(), map[string]string{
+		"phase":    phaseFinish,
+		"command":  commandLine,
+		"status":   status,
+		"exitCode": strconv.Itoa(code),
+		"duration": duration.String(),
+	})
+}
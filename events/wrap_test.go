@@ -0,0 +1,137 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		code   int
+		status string
+		output string
+	}{
+		{"success", "echo deployed", 0, statusSuccess, "deployed\n"},
+		{"failure", "echo broken; exit 3", 3, statusFailure, "broken\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, store := newEventServer(t, nil)
+
+			opts := NewOptions()
+			opts.Type = "deploy"
+			opts.Entities = []string{"e-1"}
+			opts.Tags = map[string]string{"env": "prod"}
+			opts.Command = []string{"sh", "-c", tt.script}
+			opts.Token = "test-token"
+			opts.APIURL = server.URL
+			client, err := NewClient(opts)
+			require.NoError(t, err)
+			client.output = testutil.TempFile(t)
+
+			code, err := client.Wrap(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.code, code)
+			require.Equal(t, tt.output, testutil.ReadOutput(t, client.output))
+
+			require.Len(t, store.created, 2)
+			start, finish := store.created[0], store.created[1]
+			commandLine := "sh -c " + tt.script
+
+			require.Equal(t, "Started: "+commandLine, start.Title)
+			require.Equal(t, map[string]string{"env": "prod", "phase": phaseStart, "command": commandLine}, start.Tags)
+			require.Equal(t, []string{"e-1"}, start.EntityIDs)
+
+			require.Equal(t, "deploy", finish.Name)
+			require.Equal(t, phaseFinish, finish.Tags["phase"])
+			require.Equal(t, tt.status, finish.Tags["status"])
+			require.Equal(t, strconv.Itoa(tt.code), finish.Tags["exitCode"])
+			require.NotEmpty(t, finish.Tags["duration"])
+			require.Contains(t, finish.Description, "exited with status")
+			require.GreaterOrEqual(t, finish.Timestamp, start.Timestamp)
+		})
+	}
+}
+
+func TestWrapCommandNotFound(t *testing.T) {
+	server, store := newEventServer(t, nil)
+
+	opts := NewOptions()
+	opts.Title = "Deploy"
+	opts.Command = []string{"swo-test-command-that-does-not-exist"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	_, err = client.Wrap(context.Background())
+	require.Error(t, err)
+
+	require.Len(t, store.created, 2)
+	require.Equal(t, "Started: Deploy", store.created[0].Title)
+	require.Equal(t, "Failed: Deploy", store.created[1].Title)
+	require.Equal(t, statusFailure, store.created[1].Tags["status"])
+}
+
+func TestWrapIgnoresEventFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	opts := NewOptions()
+	opts.Command = []string{"sh", "-c", "exit 0"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	code, err := client.Wrap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0, code)
+}
+
+func TestWrapDryRun(t *testing.T) {
+	server, store := newEventServer(t, nil)
+	marker := filepath.Join(t.TempDir(), "deployed")
+
+	opts := NewOptions()
+	opts.Title = "Deploy"
+	opts.DryRun = true
+	opts.Command = []string{"touch", marker}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	code, err := client.Wrap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0, code)
+	require.Empty(t, store.created)
+	require.NoFileExists(t, marker)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Equal(t, 2, strings.Count(output, "POST "+server.URL+"/v1/changeevents\n"))
+	require.Contains(t, output, `"title": "Started: Deploy"`)
+	require.Contains(t, output, "Dry run: command \"touch "+marker+"\" was not run")
+	require.Contains(t, output, `"title": "Finished: Deploy"`)
+}
+
+func TestOwnProcessGroup(t *testing.T) {
+	require.False(t, ownProcessGroup(os.Getpid()))
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+
+package events
+
+import "syscall"
+
+// ownProcessGroup reports whether the process runs in another process group than
+// the CLI, so it does not receive the interrupts of the terminal
+func ownProcessGroup(pid int) bool {
+	pgid, err := syscall.Getpgid(pid)
+	return err == nil && pgid != syscall.Getpgrp()
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package events
+
+// ownProcessGroup reports whether the process runs in another process group than
+// the CLI. Console interrupts reach every process attached to the console.
+func ownProcessGroup(_ int) bool {
+	return false
+}
@@ -0,0 +1,43 @@
+// Package testutil provides fixtures shared by the tests of the command packages
+package testutil
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TempFile creates a file that stands in for the output or input of a client. It is
+// not a terminal, so commands never ask for confirmation.
+func TempFile(t *testing.T) *os.File {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "test-output")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+	return f
+}
+
+// ReadOutput returns everything written to a file created by TempFile
+func ReadOutput(t *testing.T, f *os.File) string {
+	t.Helper()
+	_, err := f.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	output, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return string(output)
+}
+
+// WriteJSON encodes v as the JSON response of a test server
+func WriteJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("Failed to encode response: %v", err)
+	}
+}
@@ -21,7 +21,7 @@
 	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
 	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
 	// ErrInvalidDateTime indicates a timestamp could not be parsed
-	ErrInvalidDateTime = errors.New("could not parse timestamp")
+	ErrInvalidDateTime = shared.ErrInvalidDateTime
 	// ErrNoContent indicates an empty response body was received from the API
 	ErrNoContent = errors.New("no content")
 )
@@ -87,17 +87,15 @@ func (c *Client) prepareRequest(ctx context.Context, nextPage string) (*http.Req
 			params.Add("endTime", c.opts.maxTime)
 		}
 
-		var filter string
+		var terms []string
 		if c.opts.system != "" {
-			filter = fmt.Sprintf(`host:"%s"`, c.opts.system)
+			terms = append(terms, fmt.Sprintf(`host:"%s"`, c.opts.system))
 		}
-		if len(c.opts.args) != 0 {
-			if len(filter) == 0 {
-				filter = strings.Join(c.opts.args, " ")
-			} else {
-				filter = filter + " " + strings.Join(c.opts.args, " ")
-			}
+		if c.opts.trace != "" {
+			terms = append(terms, fmt.Sprintf(`trace_id:"%s"`, c.opts.trace))
 		}
+		terms = append(terms, c.opts.args...)
+		filter := strings.Join(terms, " ")
 
 		if filter != "" {
 			params.Add("filter", filter)
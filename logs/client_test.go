@@ -86,6 +86,25 @@ func TestPrepareRequest(t *testing.T) {
 				"filter": {`host:"systemValue"`},
 			},
 		},
+		{
+			name:    "trace flag",
+			options: &Options{BaseOptions: shared.BaseOptions{Token: "123456"}, trace: "4bf92f3577b34da6"},
+			expectedValues: map[string][]string{
+				"filter": {`trace_id:"4bf92f3577b34da6"`},
+			},
+		},
+		{
+			name: "system and trace flags with filter",
+			options: &Options{
+				BaseOptions: shared.BaseOptions{Token: "123456"},
+				args:        []string{"timeout"},
+				system:      "systemValue",
+				trace:       "4bf92f3577b34da6",
+			},
+			expectedValues: map[string][]string{
+				"filter": {`host:"systemValue" trace_id:"4bf92f3577b34da6" timeout`},
+			},
+		},
 		{
 			name: "system flag with filter",
 			options: &Options{
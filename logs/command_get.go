@@ -13,29 +13,45 @@
 	ConfigContextKey  = "config"
 	GroupContextKey   = "group"
 	SystemContextKey  = "system"
+	TraceContextKey   = "trace"
 	MaxTimeContextKey = "max-time"
 	MinTimeContextKey = "min-time"
 	JSONContextKey    = "json"
 	FollowContextKey  = "follow"
 )
 
+// DefaultTraceMinTime is the earliest time searched with --trace unless --min-time
+// is set, since a trace is often looked at long after it was recorded
+const DefaultTraceMinTime = "1 day ago"
+
 var flagsGet = []cli.Flag{
 	&cli.StringFlag{Name: GroupContextKey, Aliases: []string{"g"}, Usage: "group name to search"},
-	&cli.StringFlag{Name: MinTimeContextKey, Usage: "earliest time to search from", Value: "1 hour ago"},
+	&cli.StringFlag{Name: MinTimeContextKey, Usage: "earliest time to search from, \"" + DefaultTraceMinTime + "\" with --trace", Value: "1 hour ago"},
 	&cli.StringFlag{Name: MaxTimeContextKey, Usage: "latest time to search from"},
 	&cli.StringFlag{Name: SystemContextKey, Aliases: []string{"s"}, Usage: "system to search"},
+	&cli.StringFlag{Name: TraceContextKey, Usage: "only logs correlated with this trace ID"},
 	&cli.BoolFlag{Name: JSONContextKey, Aliases: []string{"j"}, Usage: "output raw JSON", Value: false},
 	&cli.BoolFlag{Name: FollowContextKey, Aliases: []string{"f"}, Usage: "enable live tailing", Value: false},
 }
 
+// minTime returns the --min-time flag, widened to DefaultTraceMinTime for --trace
+// when it was not set
+func minTime(cCtx *cli.Context) string {
+	if cCtx.String(TraceContextKey) != "" && !cCtx.IsSet(MinTimeContextKey) {
+		return DefaultTraceMinTime
+	}
+	return cCtx.String(MinTimeContextKey)
+}
+
 func runGet(cCtx *cli.Context) error {
 	opts := &Options{
 		args:       cCtx.Args().Slice(),
 		configFile: cCtx.String(ConfigContextKey),
 		group:      cCtx.String(GroupContextKey),
 		system:     cCtx.String(SystemContextKey),
+		trace:      cCtx.String(TraceContextKey),
 		maxTime:    cCtx.String(MaxTimeContextKey),
-		minTime:    cCtx.String(MinTimeContextKey),
+		minTime:    minTime(cCtx),
 		json:       cCtx.Bool(JSONContextKey),
 		follow:     cCtx.Bool(FollowContextKey),
 		BaseOptions: shared.BaseOptions{
@@ -74,6 +90,7 @@ func NewGetCommand() *cli.Command {
    swo logs get -f "(www OR db) (nginx OR pgsql) -accepted"
    swo logs get -f -g <SWO_GROUP_NAME> "(nginx OR pgsql) -accepted"
    swo logs get --min-time 'yesterday at noon' --max-time 'today at 4am' -g <SWO_GROUP_NAME>
+   swo logs get --trace 4bf92f3577b34da6a3ce929d0e0e4736
    swo logs get -- -redis
 `,
 		Action: runGet,
@@ -2,10 +2,8 @@
 
 import (
 	"errors"
-	"strings"
 	"time"
 
-	"github.com/olebedev/when"
 	"github.com/solarwinds/swo-cli/shared"
 )
 
@@ -14,28 +12,6 @@
 
 	errMinTimeFlag = errors.New("failed to parse --min-time flag")
 	errMaxTimeFlag = errors.New("failed to parse --max-time flag")
-
-	timeLayouts = []string{
-		time.Layout,
-		time.ANSIC,
-		time.UnixDate,
-		time.RubyDate,
-		time.RFC822,
-		time.RFC822Z,
-		time.RFC850,
-		time.RFC1123,
-		time.RFC1123Z,
-		time.RFC3339,
-		time.RFC3339Nano,
-		time.Kitchen,
-		time.Stamp,
-		time.StampMilli,
-		time.StampNano,
-		time.DateTime,
-		time.DateOnly,
-		time.TimeOnly,
-		"2006-01-02 15:04:05",
-	}
 )
 
 // Options represents the command line options for the logs command
@@ -45,6 +21,7 @@ type Options struct {
 	configFile         string
 	group              string
 	system             string
+	trace              string
 	maxTime            string
 	minTime            string
 	json               bool
@@ -86,33 +63,10 @@ func (opts *Options) Init(args []string) error {
 }
 
 func parseTime(input string) (string, error) {
-	location := time.Local
-	if strings.HasSuffix(input, " UTC") {
-		l, err := time.LoadLocation("UTC")
-		if err != nil {
-			return "", err
-		}
-
-		location = l
-
-		input = strings.ReplaceAll(input, " UTC", "")
-	}
-
-	for _, layout := range timeLayouts {
-		result, err := time.Parse(layout, input)
-		if err == nil {
-			result = result.In(location)
-			return result.Format(time.RFC3339), nil
-		}
-	}
-
-	result, err := when.EN.Parse(input, now)
+	result, err := shared.ParseTime(input, now)
 	if err != nil {
 		return "", err
 	}
-	if result == nil {
-		return "", ErrInvalidDateTime
-	}
 
-	return result.Time.In(location).Format(time.RFC3339), nil
+	return result.Format(time.RFC3339), nil
 }
@@ -0,0 +1,260 @@
+// Package metrics provides a client for querying metrics from the SWO API.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultPageSize for retrieving list of metrics and measurements
+	DefaultPageSize = 100
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+)
+
+// Client is a metrics client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	output     *os.File
+}
+
+// Metric describes a metric known to SWO
+type Metric struct {
+	Name             string `json:"name"`
+	DisplayName      string `json:"displayName,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Units            string `json:"units,omitempty"`
+	LastReportedTime string `json:"lastReportedTime,omitempty"`
+}
+
+// Point is a single measurement of a series
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// Attribute is a key and value a series is grouped by
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Series is the measurements of a metric for one combination of group-by values
+type Series struct {
+	Attributes   []Attribute `json:"attributes"`
+	Measurements []Point     `json:"measurements"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type listMetricsResponse struct {
+	Metrics  []Metric `json:"metricsInfo"`
+	pageInfo `json:"pageInfo"`
+}
+
+type measurementsResponse struct {
+	Groupings []Series `json:"groupings"`
+	pageInfo  `json:"pageInfo"`
+}
+
+// NewClient creates a new metrics client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		output:     os.Stdout,
+	}, nil
+}
+
+func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+	if c.opts.Name != "" {
+		params.Add("name", c.opts.Name)
+	}
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/metrics")
+}
+
+func (c *Client) prepareMeasurementsRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+	params.Add("aggregateBy", aggregations[strings.ToLower(c.opts.Aggregation)])
+	params.Add("seriesType", "TIMESERIES")
+	if c.opts.MinTime != "" {
+		params.Add("startTime", c.opts.MinTime)
+	}
+	if c.opts.MaxTime != "" {
+		params.Add("endTime", c.opts.MaxTime)
+	}
+	if c.opts.Entity != "" {
+		params.Add("filter", fmt.Sprintf("id:%s", c.opts.Entity))
+	}
+	for _, groupBy := range c.opts.GroupBy {
+		params.Add("groupBy", groupBy)
+	}
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/metrics", c.opts.Name, "measurements")
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+func (c *Client) getMetrics(ctx context.Context, nextPage string) (*listMetricsResponse, error) {
+	request, err := c.prepareListRequest(ctx, nextPage)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response listMetricsResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (c *Client) getMeasurements(ctx context.Context, nextPage string) (*measurementsResponse, error) {
+	request, err := c.prepareMeasurementsRequest(ctx, nextPage)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response measurementsResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &response, nil
+}
+
+// fetchSeries collects the measurements of all pages. Pages may continue a series
+// started on a previous page, so series with the same attributes are merged.
+func (c *Client) fetchSeries(ctx context.Context) ([]Series, error) {
+	result := []Series{}
+	index := make(map[string]int)
+	var nextPage string
+
+	for {
+		response, err := c.getMeasurements(ctx, nextPage)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, series := range response.Groupings {
+			key := seriesLabel(series.Attributes)
+			if i, ok := index[key]; ok {
+				result[i].Measurements = append(result[i].Measurements, series.Measurements...)
+				continue
+			}
+			index[key] = len(result)
+			result = append(result, series)
+		}
+
+		if response.NextPage == "" {
+			break
+		}
+		nextPage = response.NextPage
+	}
+
+	return result, nil
+}
+
+// ListMetrics retrieves and displays metrics, optionally filtered by name
+func (c *Client) ListMetrics(ctx context.Context) error {
+	var nextPage string
+
+	for {
+		response, err := c.getMetrics(ctx, nextPage)
+		if err != nil {
+			return err
+		}
+
+		if err := c.printMetrics(response.Metrics); err != nil {
+			return fmt.Errorf("failed to print result: %w", err)
+		}
+
+		if response.NextPage == "" {
+			break
+		}
+		nextPage = response.NextPage
+	}
+
+	return nil
+}
+
+// GetMeasurements retrieves and displays the measurements of a metric
+func (c *Client) GetMeasurements(ctx context.Context) error {
+	series, err := c.fetchSeries(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.printSeries(series)
+}
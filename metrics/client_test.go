@@ -0,0 +1,218 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var testSeries = []Series{
+	{
+		Attributes: []Attribute{{Key: "host", Value: "web-01"}},
+		Measurements: []Point{
+			{Time: time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC), Value: 1},
+			{Time: time.Date(2024, 5, 13, 10, 1, 0, 0, time.UTC), Value: 2.5},
+		},
+	},
+	{
+		Attributes: []Attribute{{Key: "host", Value: "web-02"}},
+		Measurements: []Point{
+			{Time: time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC), Value: 4},
+		},
+	},
+}
+
+func TestPrepareMeasurementsRequest(t *testing.T) {
+	opts := NewOptions()
+	opts.Name = "system.cpu.utilization"
+	opts.Entity = "e-1"
+	opts.MinTime = "2024-05-13T10:00:00Z"
+	opts.MaxTime = "2024-05-13T11:00:00Z"
+	opts.Aggregation = "p95"
+	opts.GroupBy = []string{"host", "region"}
+	opts.Token = "test-token"
+	opts.APIURL = "https://api.example.com"
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	request, err := client.prepareMeasurementsRequest(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, "/v1/metrics/system.cpu.utilization/measurements", request.URL.Path)
+	require.Equal(t, "Bearer test-token", request.Header.Get("Authorization"))
+
+	query := request.URL.Query()
+	require.Equal(t, "P95", query.Get("aggregateBy"))
+	require.Equal(t, "id:e-1", query.Get("filter"))
+	require.Equal(t, "2024-05-13T10:00:00Z", query.Get("startTime"))
+	require.Equal(t, "2024-05-13T11:00:00Z", query.Get("endTime"))
+	require.Equal(t, []string{"host", "region"}, query["groupBy"])
+
+	request, err = client.prepareMeasurementsRequest(context.Background(), "/v1/metrics/system.cpu.utilization/measurements?skipToken=abc")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/v1/metrics/system.cpu.utilization/measurements?skipToken=abc", request.URL.String())
+
+	// The name is a path element, it cannot start the query
+	client.opts.Name = "requests?total"
+	request, err = client.prepareMeasurementsRequest(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, "/v1/metrics/requests%3Ftotal/measurements", request.URL.EscapedPath())
+	require.Equal(t, "P95", request.URL.Query().Get("aggregateBy"))
+}
+
+func TestListMetricsPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/metrics", r.URL.Path)
+		if r.URL.Query().Get("page") == "2" {
+			testutil.WriteJSON(t, w, listMetricsResponse{Metrics: []Metric{{Name: "system.disk.io"}}})
+			return
+		}
+		require.Equal(t, "system", r.URL.Query().Get("name"))
+		testutil.WriteJSON(t, w, listMetricsResponse{
+			Metrics:  []Metric{{Name: "system.cpu.utilization", Units: "%", Description: "CPU usage"}},
+			pageInfo: pageInfo{NextPage: "/v1/metrics?page=2"},
+		})
+	}))
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.Name = "system"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.ListMetrics(context.Background()))
+	require.Equal(t, "system.cpu.utilization [%] - CPU usage\nsystem.disk.io\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestFetchSeriesMergesPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			testutil.WriteJSON(t, w, measurementsResponse{Groupings: []Series{
+				{Attributes: testSeries[0].Attributes, Measurements: testSeries[0].Measurements[1:]},
+				testSeries[1],
+			}})
+			return
+		}
+		testutil.WriteJSON(t, w, measurementsResponse{
+			Groupings: []Series{{Attributes: testSeries[0].Attributes, Measurements: testSeries[0].Measurements[:1]}},
+			pageInfo:  pageInfo{NextPage: "/v1/metrics/cpu/measurements?page=2"},
+		})
+	}))
+	defer server.Close()
+
+	opts := NewOptions()
+	opts.Name = "cpu"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	series, err := client.fetchSeries(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, testSeries, series)
+}
+
+func TestPrintSeries(t *testing.T) {
+	location, err := time.LoadLocation("GMT")
+	require.NoError(t, err)
+	time.Local = location
+
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{
+			output: OutputTable,
+			expected: "TIME                 host    VALUE\n" +
+				"2024-05-13 10:00:00  web-01  1\n" +
+				"2024-05-13 10:01:00  web-01  2.5\n" +
+				"2024-05-13 10:00:00  web-02  4\n",
+		},
+		{
+			output: OutputCSV,
+			expected: "time,host,value\n" +
+				"2024-05-13T10:00:00Z,web-01,1\n" +
+				"2024-05-13T10:01:00Z,web-01,2.5\n" +
+				"2024-05-13T10:00:00Z,web-02,4\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			opts := NewOptions()
+			opts.Output = tt.output
+			opts.Token = "test-token"
+			opts.APIURL = ""
+			client, err := NewClient(opts)
+			require.NoError(t, err)
+			client.output = testutil.TempFile(t)
+
+			require.NoError(t, client.printSeries(testSeries))
+			require.Equal(t, tt.expected, testutil.ReadOutput(t, client.output))
+		})
+	}
+}
+
+func TestPrintSeriesJSON(t *testing.T) {
+	opts := NewOptions()
+	opts.Name = "cpu"
+	opts.Aggregation = "SUM"
+	opts.Output = OutputJSON
+	opts.Token = "test-token"
+	opts.APIURL = ""
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.printSeries(testSeries))
+
+	var result struct {
+		Name        string   `json:"name"`
+		Aggregation string   `json:"aggregation"`
+		Series      []Series `json:"series"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &result))
+	require.Equal(t, "cpu", result.Name)
+	require.Equal(t, "sum", result.Aggregation)
+	require.Equal(t, testSeries, result.Series)
+}
+
+func TestPrintSeriesChart(t *testing.T) {
+	opts := NewOptions()
+	opts.Output = OutputChart
+	opts.Token = "test-token"
+	opts.APIURL = ""
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.printSeries(testSeries))
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "━━ host=web-01\n")
+	require.Contains(t, output, "━━ host=web-02\n")
+}
+
+func TestPrintSeriesSparklines(t *testing.T) {
+	opts := NewOptions()
+	opts.Chart = true
+	opts.Token = "test-token"
+	opts.APIURL = ""
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.printSeries(testSeries))
+	require.Equal(t, "SERIES       MIN  AVG   MAX  LAST  TREND\n"+
+		"host=web-01  1    1.75  2.5  2.5   ▁█\n"+
+		"host=web-02  4    4     4    4     ▅\n", testutil.ReadOutput(t, client.output))
+}
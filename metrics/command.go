@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+// NewMetricsCommand creates the metrics command
+func NewMetricsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "metrics",
+		Usage: "Query metrics from SolarWinds Observability",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List available metrics",
+				Action: runList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "name",
+						Aliases: []string{"n"},
+						Usage:   "Filter metrics by name",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "Get the measurements of a metric",
+				ArgsUsage: "NAME",
+				Action:    runGet,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "entity",
+						Usage: "Only include measurements of this entity ID",
+					},
+					&cli.StringFlag{
+						Name:  "min-time",
+						Usage: "earliest time of the measurements",
+						Value: "1 hour ago",
+					},
+					&cli.StringFlag{
+						Name:  "max-time",
+						Usage: "latest time of the measurements",
+					},
+					&cli.StringFlag{
+						Name:    "aggregation",
+						Aliases: []string{"a"},
+						Usage:   "How measurements are aggregated: avg, sum, min, max, count, last, p50, p90, p95 or p99",
+						Value:   "avg",
+					},
+					&cli.StringSliceFlag{
+						Name:    "group-by",
+						Aliases: []string{"g"},
+						Usage:   "Split the measurements into series by this tag (can be specified multiple times)",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output format: table, json, csv or chart",
+						Value:   OutputTable,
+					},
+					&cli.BoolFlag{
+						Name:  "chart",
+						Usage: "Plot the series as a line chart, or with --output table summarize each series with a sparkline",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format, same as --output json",
+					},
+				},
+			},
+			{
+				Name:      "send",
+				Usage:     "Send a measurement, or stream measurements from standard input",
+				ArgsUsage: "NAME VALUE | --stdin",
+				Description: `With --stdin every line is a measurement in the form
+
+   NAME[,TAG=VALUE...] VALUE [UNIX_TIMESTAMP]
+
+for example 'deploy.duration,service=api 42.5 1715594400'. Empty lines and lines
+starting with # are ignored. Queued measurements are sent before exiting, also
+when the command is interrupted.`,
+				Action: runSend,
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "tag",
+						Aliases: []string{"t"},
+						Usage:   "Tag in key=value format (can be specified multiple times), added to streamed lines without that tag",
+					},
+					&cli.StringFlag{
+						Name:  "entity",
+						Usage: "Entity ID the measurements belong to",
+					},
+					&cli.StringFlag{
+						Name:  "time",
+						Usage: "time of the measurement, defaults to now",
+					},
+					&cli.BoolFlag{
+						Name:  "stdin",
+						Usage: "Read measurements from standard input",
+					},
+					&cli.IntFlag{
+						Name:  "batch-size",
+						Usage: "How many measurements are sent in one request",
+						Value: DefaultBatchSize,
+					},
+					&cli.DurationFlag{
+						Name:  "flush-interval",
+						Usage: "How often queued measurements are sent while streaming",
+						Value: DefaultFlushInterval,
+					},
+					&cli.IntFlag{
+						Name:  "retries",
+						Usage: "How often a failed request is retried",
+						Value: DefaultRetries,
+					},
+				},
+			},
+		},
+	}
+}
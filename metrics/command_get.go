@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func runGet(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Name = ctx.Args().First()
+	opts.Entity = ctx.String("entity")
+	opts.MinTime = ctx.String("min-time")
+	opts.MaxTime = ctx.String("max-time")
+	opts.Aggregation = ctx.String("aggregation")
+	opts.GroupBy = ctx.StringSlice("group-by")
+	opts.Output = ctx.String("output")
+	opts.Chart = ctx.Bool("chart")
+	if opts.Chart && !ctx.IsSet("output") {
+		opts.Output = OutputChart
+	}
+	if ctx.Bool("json") {
+		opts.Output = OutputJSON
+	}
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ValidateForGet(); err != nil {
+		return err
+	}
+	if err := opts.Init(time.Now()); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.GetMeasurements(context.Background())
+}
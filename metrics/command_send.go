@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/solarwinds/swo-cli/config"
+	"github.com/solarwinds/swo-cli/shared"
+	cli "github.com/urfave/cli/v2"
+)
+
+func runSend(ctx *cli.Context) error {
+	stream := ctx.Bool("stdin")
+
+	opts := NewOptions()
+	opts.Name = ctx.Args().Get(0)
+	opts.Entity = ctx.String("entity")
+	opts.BatchSize = ctx.Int("batch-size")
+	opts.FlushInterval = ctx.Duration("flush-interval")
+	opts.Retries = ctx.Int("retries")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ParseTags(ctx.StringSlice("tag")); err != nil {
+		return err
+	}
+
+	if !stream {
+		if err := opts.ParseValue(ctx.Args().Get(1)); err != nil {
+			return err
+		}
+		if input := ctx.String("time"); input != "" {
+			t, err := shared.ParseTime(input, time.Now())
+			if err != nil {
+				return errors.Join(errTimeFlag, err)
+			}
+			opts.Time = t.UTC()
+		}
+	}
+
+	if err := opts.ValidateForSend(stream); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	if !stream {
+		return client.Send(context.Background())
+	}
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return client.SendStream(runCtx, os.Stdin)
+}
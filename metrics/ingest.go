@@ -0,0 +1,378 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultBatchSize is how many measurements are sent in one ingestion request
+	DefaultBatchSize = 100
+	// DefaultFlushInterval is how often pending measurements are sent while streaming
+	DefaultFlushInterval = 10 * time.Second
+	// DefaultRetries is how often a failed ingestion request is retried
+	DefaultRetries = 3
+
+	// flushTimeout bounds the final flush after the input ended or the command was interrupted
+	flushTimeout = 30 * time.Second
+	// maxRetainedBatches is how many failed batches are kept to be sent again
+	maxRetainedBatches = 10
+	// maxUnixSeconds is the latest timestamp a time.Time holds in nanoseconds, a
+	// larger one is usually given in milliseconds
+	maxUnixSeconds = math.MaxInt64 / int64(time.Second)
+)
+
+var (
+	errInvalidLine = errors.New("invalid line, expected NAME[,TAG=VALUE...] VALUE [UNIX_TIMESTAMP]")
+	errSendFailed  = errors.New("failed to send some measurements")
+	errRejected    = errors.New("measurements rejected")
+
+	// retryBackoff is the wait before the first retry, doubled for every further retry
+	retryBackoff = time.Second
+)
+
+// Sample is a single measurement sent to SWO
+type Sample struct {
+	Name     string            `json:"name"`
+	Value    float64           `json:"value"`
+	Time     time.Time         `json:"time"`
+	EntityID string            `json:"entityId,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+}
+
+type ingestRequest struct {
+	Metrics []Sample `json:"metrics"`
+}
+
+// parseLine parses a line of the line protocol read from standard input. Tags
+// follow the name separated by commas, like in the Influx line protocol, and the
+// optional timestamp in Unix seconds follows the value, like in Graphite:
+//
+//	deploy.duration,service=api,env=prod 42.5 1715594400
+func parseLine(line string, now time.Time) (Sample, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || len(fields) > 3 {
+		return Sample{}, fmt.Errorf("%w: %q", errInvalidLine, line)
+	}
+
+	parts := strings.Split(fields[0], ",")
+	sample := Sample{Name: parts[0], Time: now}
+	if sample.Name == "" {
+		return Sample{}, fmt.Errorf("%w: %q", errInvalidLine, line)
+	}
+	if len(parts) > 1 {
+		tags, err := parseTags(parts[1:])
+		if err != nil {
+			return Sample{}, err
+		}
+		sample.Tags = tags
+	}
+
+	value, err := parseValue(fields[1])
+	if err != nil {
+		return Sample{}, fmt.Errorf("%w: invalid value %q", errInvalidLine, fields[1])
+	}
+	sample.Value = value
+
+	if len(fields) == 3 {
+		seconds, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || math.IsNaN(seconds) || seconds < 0 || seconds > float64(maxUnixSeconds) {
+			return Sample{}, fmt.Errorf("%w: invalid timestamp %q, expected Unix seconds", errInvalidLine, fields[2])
+		}
+		sample.Time = time.Unix(0, int64(seconds*float64(time.Second))).UTC()
+	}
+
+	return sample, nil
+}
+
+// Sender batches measurements into ingestion requests. Batches that failed are
+// kept, up to maxRetainedBatches, and sent again on the next flush.
+type Sender struct {
+	client   *Client
+	pending  []Sample
+	retained [][]Sample
+	sent     int
+	failed   int
+}
+
+func (c *Client) newSender() *Sender {
+	return &Sender{client: c}
+}
+
+func (c *Client) prepareIngestRequest(ctx context.Context, samples []Sample) (*http.Request, error) {
+	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/metrics")
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(ingestRequest{Metrics: samples})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal measurements: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Accept", "application/json")
+
+	return request, nil
+}
+
+// retryable reports whether a failed ingestion request may succeed when sent again
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// post sends a batch, retrying on network errors, rate limiting and server errors
+func (c *Client) post(ctx context.Context, samples []Sample) error {
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		request, err := c.prepareIngestRequest(ctx, samples)
+		if err != nil {
+			// Preparing the same batch again fails the same way
+			return fmt.Errorf("%w: error while preparing http request to SWO: %w", errRejected, err)
+		}
+
+		if c.opts.DryRun {
+			return shared.PrintDryRunRequest(c.output, request)
+		}
+
+		slog.Debug("Sending HTTP request", "method", request.Method, "url", request.URL.String(), "measurements", len(samples)) //nolint:gosec
+
+		retry := true
+		response, err := c.httpClient.Do(request) //nolint:gosec
+		if err == nil {
+			content, _ := io.ReadAll(response.Body)
+			_ = response.Body.Close()
+
+			if response.StatusCode >= 200 && response.StatusCode <= 299 {
+				return nil
+			}
+			retry = retryable(response.StatusCode)
+			if retry {
+				err = fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+			} else {
+				err = fmt.Errorf("%w: %w: %d, response body: %s", errRejected, ErrInvalidAPIResponse, response.StatusCode, string(content))
+			}
+		} else {
+			err = fmt.Errorf("error while sending http request to SWO: %w", err)
+		}
+
+		if !retry || attempt >= c.opts.Retries {
+			return err
+		}
+
+		slog.Warn("Failed to send measurements, retrying", "attempt", attempt+1, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// Add queues a measurement and sends the batch once it is full
+func (s *Sender) Add(ctx context.Context, sample Sample) error {
+	s.pending = append(s.pending, sample)
+	if len(s.pending) >= s.client.opts.BatchSize {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// retain keeps a failed batch to be sent again. The oldest batch is dropped once
+// too many failed, so a persistent failure does not grow the queue without bounds.
+func (s *Sender) retain(batch []Sample) {
+	s.retained = append(s.retained, batch)
+	if len(s.retained) > maxRetainedBatches {
+		slog.Warn("Too many failed batches, dropping the oldest", "measurements", len(s.retained[0]))
+		s.failed += len(s.retained[0])
+		s.retained = s.retained[1:]
+	}
+}
+
+// Flush sends the batches that failed before and all queued measurements
+func (s *Sender) Flush(ctx context.Context) error {
+	batches := s.retained
+	if len(s.pending) > 0 {
+		batches = append(batches, s.pending)
+	}
+	s.retained = nil
+	s.pending = nil
+
+	var flushErr error
+	for _, batch := range batches {
+		if err := s.client.post(ctx, batch); err != nil {
+			// Sending a rejected batch again would only be rejected again
+			if errors.Is(err, errRejected) {
+				s.failed += len(batch)
+			} else {
+				s.retain(batch)
+			}
+			flushErr = err
+			continue
+		}
+		s.sent += len(batch)
+	}
+	return flushErr
+}
+
+// finish flushes what is left, even if the context was cancelled by an interrupt.
+// Batches that still fail are counted as failed.
+func (s *Sender) finish(ctx context.Context) error {
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancel()
+
+	err := s.Flush(flushCtx)
+	for _, batch := range s.retained {
+		s.failed += len(batch)
+	}
+	s.retained = nil
+	return err
+}
+
+func (c *Client) printSendResult(sender *Sender, skipped int) {
+	if c.opts.DryRun {
+		return
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Sent %d measurements", sender.sent)
+	if sender.failed > 0 {
+		_, _ = fmt.Fprintf(c.output, ", %d failed", sender.failed)
+	}
+	if skipped > 0 {
+		_, _ = fmt.Fprintf(c.output, ", skipped %d invalid lines", skipped)
+	}
+	_, _ = fmt.Fprintln(c.output)
+}
+
+// Send submits a single measurement given on the command line
+func (c *Client) Send(ctx context.Context) error {
+	sample := Sample{Name: c.opts.Name, Value: c.opts.Value, Time: c.opts.Time, EntityID: c.opts.Entity, Tags: c.opts.Tags}
+	if sample.Time.IsZero() {
+		sample.Time = time.Now().UTC()
+	}
+
+	sender := c.newSender()
+	sender.pending = []Sample{sample}
+	if err := sender.finish(ctx); err != nil {
+		return err
+	}
+
+	c.printSendResult(sender, 0)
+	return nil
+}
+
+// SendStream reads measurements in the line protocol from the reader until it
+// ends or the context is cancelled. Measurements are sent when a batch is full
+// and every flush interval; whatever is still queued is sent before returning.
+// Cancelling the context only stops reading, a request that is being sent or
+// retried is not interrupted, so no batch is lost to an interrupt.
+func (c *Client) SendStream(ctx context.Context, r io.Reader) error {
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	ticker := time.NewTicker(c.opts.FlushInterval)
+	defer ticker.Stop()
+
+	sendCtx := context.WithoutCancel(ctx)
+	sender := c.newSender()
+	lineNumber, skipped := 0, 0
+	var sendErr error
+
+loop:
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				break loop
+			}
+			lineNumber++
+
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			sample, err := parseLine(line, time.Now().UTC())
+			if err != nil {
+				slog.Warn("Skipping invalid line", "line", lineNumber, "error", err)
+				skipped++
+				continue
+			}
+			for key, value := range c.opts.Tags {
+				if _, ok := sample.Tags[key]; !ok {
+					if sample.Tags == nil {
+						sample.Tags = make(map[string]string)
+					}
+					sample.Tags[key] = value
+				}
+			}
+			sample.EntityID = c.opts.Entity
+
+			if err := sender.Add(sendCtx, sample); err != nil {
+				slog.Error("Failed to send measurements, keeping them for the next flush", "error", err)
+				sendErr = err
+			}
+		case <-ticker.C:
+			if err := sender.Flush(sendCtx); err != nil {
+				slog.Error("Failed to send measurements, keeping them for the next flush", "error", err)
+				sendErr = err
+			}
+		case <-ctx.Done():
+			slog.Info("Interrupted, sending queued measurements")
+			break loop
+		}
+	}
+
+	if err := sender.finish(ctx); err != nil {
+		sendErr = err
+	}
+
+	c.printSendResult(sender, skipped)
+
+	select {
+	case err := <-readErr:
+		if err != nil {
+			return fmt.Errorf("failed to read measurements: %w", err)
+		}
+	default:
+	}
+
+	// Batches that failed on the way may still have been sent by the final flush
+	if sender.failed > 0 {
+		return fmt.Errorf("%w: %d of %d: %w", errSendFailed, sender.failed, sender.sent+sender.failed, sendErr)
+	}
+	return nil
+}
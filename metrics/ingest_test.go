@@ -0,0 +1,360 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// ingestServer records the received batches and fails the first failures requests
+type ingestServer struct {
+	mu       sync.Mutex
+	batches  [][]Sample
+	requests int
+	failures int
+	status   int
+}
+
+func newIngestServer(t *testing.T, failures int, status int) (*httptest.Server, *ingestServer) {
+	store := &ingestServer{failures: failures, status: status}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/v1/metrics", r.URL.Path)
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		store.requests++
+		if store.requests <= store.failures {
+			w.WriteHeader(store.status)
+			return
+		}
+
+		var request ingestRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		store.batches = append(store.batches, request.Metrics)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, store
+}
+
+func noBackoff(t *testing.T) {
+	backoff := retryBackoff
+	retryBackoff = time.Millisecond
+	t.Cleanup(func() {
+		retryBackoff = backoff
+	})
+}
+
+func TestParseLine(t *testing.T) {
+	now := time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC)
+
+	sample, err := parseLine("deploy.duration,service=api,env=prod 42.5 1715594400", now)
+	require.NoError(t, err)
+	require.Equal(t, Sample{
+		Name:  "deploy.duration",
+		Value: 42.5,
+		Time:  time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC),
+		Tags:  map[string]string{"service": "api", "env": "prod"},
+	}, sample)
+
+	sample, err = parseLine("jobs.processed 7", now)
+	require.NoError(t, err)
+	require.Equal(t, Sample{Name: "jobs.processed", Value: 7, Time: now}, sample)
+
+	for _, line := range []string{
+		"jobs.processed", "jobs.processed seven", "jobs 1 yesterday", ",a=b 1", "jobs,a 1", "a 1 2 3",
+		"jobs NaN", "jobs Inf", "jobs -Inf", "jobs 1 1715594400000", "jobs 1 -1", "jobs 1 NaN",
+	} {
+		_, err := parseLine(line, now)
+		require.Error(t, err, line)
+	}
+}
+
+func TestSend(t *testing.T) {
+	server, store := newIngestServer(t, 0, 0)
+
+	opts := NewOptions()
+	opts.Name = "backup.size"
+	opts.Value = 1024
+	opts.Entity = "e-1"
+	opts.Tags = map[string]string{"job": "nightly"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.Send(context.Background()))
+	require.Len(t, store.batches, 1)
+	require.Equal(t, "backup.size", store.batches[0][0].Name)
+	require.Equal(t, float64(1024), store.batches[0][0].Value)
+	require.Equal(t, "e-1", store.batches[0][0].EntityID)
+	require.Equal(t, map[string]string{"job": "nightly"}, store.batches[0][0].Tags)
+	require.False(t, store.batches[0][0].Time.IsZero())
+	require.Equal(t, "Sent 1 measurements\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestSendRetries(t *testing.T) {
+	noBackoff(t)
+
+	tests := []struct {
+		name     string
+		failures int
+		status   int
+		requests int
+		wantErr  bool
+	}{
+		{"server error is retried", 2, http.StatusServiceUnavailable, 3, false},
+		{"rate limit is retried", 1, http.StatusTooManyRequests, 2, false},
+		{"retries are exhausted", 5, http.StatusBadGateway, DefaultRetries + 1, true},
+		{"client error is not retried", 1, http.StatusBadRequest, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, store := newIngestServer(t, tt.failures, tt.status)
+
+			opts := NewOptions()
+			opts.Name = "backup.size"
+			opts.Token = "test-token"
+			opts.APIURL = server.URL
+			client, err := NewClient(opts)
+			require.NoError(t, err)
+			client.output = testutil.TempFile(t)
+
+			err = client.Send(context.Background())
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidAPIResponse)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.requests, store.requests)
+		})
+	}
+}
+
+func TestSendStream(t *testing.T) {
+	server, store := newIngestServer(t, 0, 0)
+
+	opts := NewOptions()
+	opts.BatchSize = 2
+	opts.Entity = "e-1"
+	opts.Tags = map[string]string{"env": "prod", "service": "default"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	input := strings.Join([]string{
+		"# nightly job",
+		"jobs.processed,service=billing 10",
+		"",
+		"jobs.failed 1 1715594400",
+		"not a measurement",
+		"jobs.duration 12.5",
+	}, "\n")
+
+	require.NoError(t, client.SendStream(context.Background(), strings.NewReader(input)))
+
+	// Full batches are sent right away, the rest when the input ends
+	require.Len(t, store.batches, 2)
+	require.Len(t, store.batches[0], 2)
+	require.Len(t, store.batches[1], 1)
+
+	first := store.batches[0][0]
+	require.Equal(t, "jobs.processed", first.Name)
+	require.Equal(t, "e-1", first.EntityID)
+	require.Equal(t, map[string]string{"env": "prod", "service": "billing"}, first.Tags)
+	require.Equal(t, time.Unix(1715594400, 0).UTC(), store.batches[0][1].Time)
+
+	require.Equal(t, "Sent 3 measurements, skipped 1 invalid lines\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestSendStreamFlushesOnCancel(t *testing.T) {
+	server, store := newIngestServer(t, 0, 0)
+
+	opts := NewOptions()
+	opts.FlushInterval = time.Hour
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = reader.Close()
+		_ = writer.Close()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- client.SendStream(ctx, reader)
+	}()
+
+	_, err = writer.WriteString("jobs.processed 1\njobs.processed 2\n")
+	require.NoError(t, err)
+
+	// Let the stream queue both lines, then interrupt it while the input is still open
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	require.Len(t, store.batches, 1)
+	require.Len(t, store.batches[0], 2)
+}
+
+func TestSendStreamRetainsFailedBatches(t *testing.T) {
+	noBackoff(t)
+	// Every attempt for the first batch fails, it is sent again with the next one
+	server, store := newIngestServer(t, DefaultRetries+1, http.StatusServiceUnavailable)
+
+	opts := NewOptions()
+	opts.BatchSize = 1
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	input := "jobs.processed 1\njobs.processed 2\n"
+	require.NoError(t, client.SendStream(context.Background(), strings.NewReader(input)))
+
+	require.Len(t, store.batches, 2)
+	require.Equal(t, float64(1), store.batches[0][0].Value)
+	require.Equal(t, float64(2), store.batches[1][0].Value)
+	require.Equal(t, "Sent 2 measurements\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestSendStreamDropsRejectedBatches(t *testing.T) {
+	server, store := newIngestServer(t, 1, http.StatusBadRequest)
+
+	opts := NewOptions()
+	opts.BatchSize = 1
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	input := "jobs.processed 1\njobs.processed 2\n"
+	err = client.SendStream(context.Background(), strings.NewReader(input))
+	require.ErrorIs(t, err, errSendFailed)
+
+	require.Equal(t, 2, store.requests)
+	require.Equal(t, "Sent 1 measurements, 1 failed\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestSendStreamSkipsNonFiniteValues(t *testing.T) {
+	server, store := newIngestServer(t, 0, 0)
+
+	opts := NewOptions()
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	input := "jobs.processed 1\njobs.processed NaN\njobs.processed 2\n"
+	require.NoError(t, client.SendStream(context.Background(), strings.NewReader(input)))
+
+	require.Len(t, store.batches, 1)
+	require.Len(t, store.batches[0], 2)
+	require.Equal(t, "Sent 2 measurements, skipped 1 invalid lines\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestSendUnmarshalableBatchIsNotRetried(t *testing.T) {
+	noBackoff(t)
+	server, store := newIngestServer(t, 0, 0)
+
+	opts := NewOptions()
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	sender := client.newSender()
+	require.NoError(t, sender.Add(context.Background(), Sample{Name: "jobs.processed", Value: math.NaN()}))
+	require.ErrorIs(t, sender.Flush(context.Background()), errRejected)
+	require.Empty(t, sender.retained)
+	require.Equal(t, 1, sender.failed)
+	require.Zero(t, store.requests)
+}
+
+func TestSendStreamInterruptDuringBackoff(t *testing.T) {
+	backoff := retryBackoff
+	retryBackoff = 200 * time.Millisecond
+	t.Cleanup(func() {
+		retryBackoff = backoff
+	})
+	server, store := newIngestServer(t, 1, http.StatusServiceUnavailable)
+
+	opts := NewOptions()
+	opts.BatchSize = 1
+	opts.FlushInterval = time.Hour
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = reader.Close()
+		_ = writer.Close()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- client.SendStream(ctx, reader)
+	}()
+
+	_, err = writer.WriteString("jobs.processed 1\n")
+	require.NoError(t, err)
+
+	// Interrupt while the failed first attempt waits for its retry
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	require.Len(t, store.batches, 1)
+	require.Equal(t, "Sent 1 measurements\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestSendDryRun(t *testing.T) {
+	server, store := newIngestServer(t, 0, 0)
+
+	opts := NewOptions()
+	opts.Name = "backup.size"
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.Send(context.Background()))
+	require.Equal(t, 0, store.requests)
+	require.True(t, strings.HasPrefix(testutil.ReadOutput(t, client.output), "POST "+server.URL+"/v1/metrics\n"))
+}
@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// OutputTable prints measurements as an aligned table
+	OutputTable = "table"
+	// OutputJSON prints measurements as JSON
+	OutputJSON = "json"
+	// OutputCSV prints measurements as comma separated values
+	OutputCSV = "csv"
+	// OutputChart plots measurements as a chart
+	OutputChart = "chart"
+)
+
+var (
+	errMissingMetricName  = errors.New("metric name is required")
+	errTimeFlag           = errors.New("failed to parse --time flag")
+	errInvalidAggregation = errors.New("invalid aggregation")
+	errInvalidOutput      = errors.New("invalid output format, expected table, json, csv or chart")
+	errInvalidTag         = errors.New("invalid tag format, expected key=value")
+	errInvalidValue       = errors.New("invalid metric value")
+	errInvalidBatchSize   = errors.New("batch size must be at least 1")
+	errInvalidInterval    = errors.New("flush interval must be positive")
+
+	// aggregations maps the accepted --aggregation values to the API values
+	aggregations = map[string]string{
+		"avg":   "AVG",
+		"sum":   "SUM",
+		"min":   "MIN",
+		"max":   "MAX",
+		"count": "COUNT",
+		"last":  "LAST",
+		"p50":   "P50",
+		"p90":   "P90",
+		"p95":   "P95",
+		"p99":   "P99",
+	}
+)
+
+// Options represents the command line options for the metrics command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	Name               string
+	Entity             string
+	MinTime            string
+	MaxTime            string
+	Aggregation        string
+	GroupBy            []string
+	Output             string
+	Chart              bool
+	Value              float64
+	Time               time.Time
+	Tags               map[string]string
+	BatchSize          int
+	FlushInterval      time.Duration
+	Retries            int
+	JSON               bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{
+		Aggregation:   "avg",
+		Output:        OutputTable,
+		BatchSize:     DefaultBatchSize,
+		FlushInterval: DefaultFlushInterval,
+		Retries:       DefaultRetries,
+	}
+}
+
+// Init parses the time flags into RFC3339 timestamps relative to now
+func (o *Options) Init(now time.Time) error {
+	minTime, maxTime, err := shared.ParseTimeRange(o.MinTime, o.MaxTime, now)
+	if err != nil {
+		return err
+	}
+
+	o.MinTime, o.MaxTime = minTime, maxTime
+	return nil
+}
+
+// ValidateForGet validates options for get operation
+func (o *Options) ValidateForGet() error {
+	if strings.TrimSpace(o.Name) == "" {
+		return errMissingMetricName
+	}
+	if _, ok := aggregations[strings.ToLower(o.Aggregation)]; !ok {
+		return fmt.Errorf("%w: %s, expected avg, sum, min, max, count, last, p50, p90, p95 or p99", errInvalidAggregation, o.Aggregation)
+	}
+	switch o.Output {
+	case OutputTable, OutputJSON, OutputCSV, OutputChart:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errInvalidOutput, o.Output)
+	}
+}
+
+// parseTags parses tag strings in key=value format into a map
+func parseTags(tagStrings []string) (map[string]string, error) {
+	tags := make(map[string]string, len(tagStrings))
+	for _, tagStr := range tagStrings {
+		key, value, ok := strings.Cut(tagStr, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: %s", errInvalidTag, tagStr)
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
+// parseValue parses the value of a measurement, which has to be a finite number
+// to be sent as JSON
+func parseValue(value string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errInvalidValue
+	}
+	return v, nil
+}
+
+// ParseValue parses the value of a measurement given on the command line
+func (o *Options) ParseValue(value string) error {
+	v, err := parseValue(value)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidValue, value)
+	}
+	o.Value = v
+	return nil
+}
+
+// ParseTags parses tag strings in key=value format into the options
+func (o *Options) ParseTags(tagStrings []string) error {
+	tags, err := parseTags(tagStrings)
+	if err != nil {
+		return err
+	}
+	o.Tags = tags
+	return nil
+}
+
+// ValidateForSend validates options for send operation. The metric name is only
+// required for a single measurement, streamed lines name their own metric.
+func (o *Options) ValidateForSend(stream bool) error {
+	if !stream && strings.TrimSpace(o.Name) == "" {
+		return errMissingMetricName
+	}
+	if o.BatchSize < 1 {
+		return errInvalidBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		return errInvalidInterval
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	now := time.Date(2000, 1, 1, 10, 0, 30, 0, time.UTC)
+
+	opts := NewOptions()
+	opts.MinTime = "2000-01-01T09:00:30Z"
+	opts.MaxTime = "2000-01-01 10:00:00 UTC"
+	require.NoError(t, opts.Init(now))
+	require.Equal(t, "2000-01-01T09:00:30Z", opts.MinTime)
+	require.Equal(t, "2000-01-01T10:00:00Z", opts.MaxTime)
+
+	opts = NewOptions()
+	opts.MinTime = "what?"
+	require.ErrorIs(t, opts.Init(now), shared.ErrMinTimeFlag)
+
+	opts = NewOptions()
+	opts.MaxTime = "what?"
+	require.ErrorIs(t, opts.Init(now), shared.ErrMaxTimeFlag)
+
+	opts = NewOptions()
+	opts.MinTime = "2000-01-01T10:00:00Z"
+	opts.MaxTime = "2000-01-01T09:00:00Z"
+	require.ErrorIs(t, opts.Init(now), shared.ErrInvalidTimeRange)
+}
+
+func TestValidateForGet(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingMetricName, opts.ValidateForGet())
+
+	opts.Name = "system.cpu.utilization"
+	require.NoError(t, opts.ValidateForGet())
+
+	opts.Aggregation = "P95"
+	require.NoError(t, opts.ValidateForGet())
+
+	opts.Aggregation = "median"
+	require.ErrorIs(t, opts.ValidateForGet(), errInvalidAggregation)
+
+	opts.Aggregation = "avg"
+	opts.Output = "xml"
+	require.ErrorIs(t, opts.ValidateForGet(), errInvalidOutput)
+}
+
+func TestValidateForSend(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingMetricName, opts.ValidateForSend(false))
+	require.NoError(t, opts.ValidateForSend(true))
+
+	opts.Name = "backup.size"
+	require.NoError(t, opts.ValidateForSend(false))
+
+	opts.BatchSize = 0
+	require.Equal(t, errInvalidBatchSize, opts.ValidateForSend(false))
+
+	opts.BatchSize = DefaultBatchSize
+	opts.FlushInterval = 0
+	require.Equal(t, errInvalidInterval, opts.ValidateForSend(true))
+}
+
+func TestParseValueAndTags(t *testing.T) {
+	opts := NewOptions()
+	require.NoError(t, opts.ParseValue(" 12.5 "))
+	require.Equal(t, 12.5, opts.Value)
+	require.ErrorIs(t, opts.ParseValue("twelve"), errInvalidValue)
+	require.ErrorIs(t, opts.ParseValue("NaN"), errInvalidValue)
+	require.ErrorIs(t, opts.ParseValue("-Inf"), errInvalidValue)
+
+	require.NoError(t, opts.ParseTags([]string{"env=prod", "service = api"}))
+	require.Equal(t, map[string]string{"env": "prod", "service": "api"}, opts.Tags)
+	require.ErrorIs(t, opts.ParseTags([]string{"env"}), errInvalidTag)
+	require.ErrorIs(t, opts.ParseTags([]string{"=prod"}), errInvalidTag)
+}
@@ -0,0 +1,186 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/solarwinds/swo-cli/chart"
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+// seriesLabel joins the group-by values of a series, e.g. "host=web-01, region=eu"
+func seriesLabel(attributes []Attribute) string {
+	if len(attributes) == 0 {
+		return "all"
+	}
+
+	parts := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		parts[i] = attribute.Key + "=" + attribute.Value
+	}
+	return strings.Join(parts, ", ")
+}
+
+func attributeValue(attributes []Attribute, key string) string {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+// groupKeys returns the sorted attribute keys of all series
+func groupKeys(series []Series) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, s := range series {
+		for _, attribute := range s.Attributes {
+			if !seen[attribute.Key] {
+				seen[attribute.Key] = true
+				keys = append(keys, attribute.Key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (c *Client) printMetrics(metrics []Metric) error {
+	for _, metric := range metrics {
+		if c.opts.JSON {
+			jsonData, err := json.Marshal(metric)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(c.output, string(jsonData))
+			continue
+		}
+
+		line := metric.Name
+		if metric.Units != "" {
+			line += " [" + metric.Units + "]"
+		}
+		if metric.Description != "" {
+			line += " - " + metric.Description
+		}
+		_, _ = fmt.Fprintln(c.output, line)
+	}
+	return nil
+}
+
+func writeTable(w io.Writer, series []Series) error {
+	keys := groupKeys(series)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	header := append([]string{"TIME"}, keys...)
+	_, _ = fmt.Fprintln(tw, strings.Join(append(header, "VALUE"), "\t"))
+	for _, s := range series {
+		for _, point := range s.Measurements {
+			row := []string{point.Time.Local().Format(time.DateTime)}
+			for _, key := range keys {
+				row = append(row, attributeValue(s.Attributes, key))
+			}
+			_, _ = fmt.Fprintln(tw, strings.Join(append(row, formatValue(point.Value)), "\t"))
+		}
+	}
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, series []Series) error {
+	keys := groupKeys(series)
+
+	cw := csv.NewWriter(w)
+	header := append([]string{"time"}, keys...)
+	if err := cw.Write(append(header, "value")); err != nil {
+		return err
+	}
+	for _, s := range series {
+		for _, point := range s.Measurements {
+			row := []string{point.Time.UTC().Format(time.RFC3339)}
+			for _, key := range keys {
+				row = append(row, attributeValue(s.Attributes, key))
+			}
+			if err := cw.Write(append(row, formatValue(point.Value))); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// chartSeries converts the series for the chart renderer
+func chartSeries(series []Series) []chart.Series {
+	result := make([]chart.Series, len(series))
+	for i, s := range series {
+		result[i].Name = seriesLabel(s.Attributes)
+		result[i].Points = make([]chart.Point, len(s.Measurements))
+		for j, point := range s.Measurements {
+			result[i].Points[j] = chart.Point{Time: point.Time, Value: point.Value}
+		}
+	}
+	return result
+}
+
+// writeSparklineTable summarizes every series in one row with a sparkline of its values
+func writeSparklineTable(w io.Writer, series []Series) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SERIES\tMIN\tAVG\tMAX\tLAST\tTREND")
+	for _, s := range series {
+		if len(s.Measurements) == 0 {
+			continue
+		}
+
+		values := make([]float64, len(s.Measurements))
+		low, high, sum := math.Inf(1), math.Inf(-1), 0.0
+		for i, point := range s.Measurements {
+			values[i] = point.Value
+			low = math.Min(low, point.Value)
+			high = math.Max(high, point.Value)
+			sum += point.Value
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", seriesLabel(s.Attributes),
+			chart.FormatValue(low), chart.FormatValue(sum/float64(len(values))), chart.FormatValue(high),
+			chart.FormatValue(values[len(values)-1]), chart.Sparkline(values))
+	}
+	return tw.Flush()
+}
+
+func (c *Client) printSeries(series []Series) error {
+	switch c.opts.Output {
+	case OutputJSON:
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"name":        c.opts.Name,
+			"aggregation": strings.ToLower(c.opts.Aggregation),
+			"series":      series,
+		})
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	case OutputCSV:
+		return writeCSV(c.output, series)
+	case OutputChart:
+		return chart.Line(c.output, chartSeries(series), chart.Options{Color: shared.IsTerminal(c.output)})
+	default:
+		if c.opts.Chart {
+			return writeSparklineTable(c.output, series)
+		}
+		return writeTable(c.output, series)
+	}
+}
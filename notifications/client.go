@@ -0,0 +1,438 @@
+// Package notifications provides a client for managing notification services,
+// the channels alerts are sent to, in the SWO API.
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultPageSize for retrieving list of notification services
+	DefaultPageSize = 100
+
+	redactedValue = "<redacted>"
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+
+	errTestFailed = errors.New("test notification failed")
+
+	// sensitiveSettings are never printed, they grant access to the channel. Keys
+	// are lower case and matched case-insensitively. Slack and webhook URLs embed
+	// their secret.
+	sensitiveSettings = map[string]bool{
+		"routingkey": true,
+		"secret":     true,
+		"token":      true,
+		"password":   true,
+		"url":        true,
+	}
+)
+
+// Client is a notifications client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	output     *os.File
+}
+
+// NotificationService is a configured notification channel
+type NotificationService struct {
+	ID          string                 `json:"id,omitempty"`
+	Type        string                 `json:"type"`
+	Name        string                 `json:"title"`
+	Description string                 `json:"description,omitempty"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+	CreatedAt   string                 `json:"createdAt,omitempty"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type listServicesResponse struct {
+	Services []NotificationService `json:"notificationServices"`
+	pageInfo `json:"pageInfo"`
+}
+
+type createServiceResponse struct {
+	ID string `json:"id"`
+}
+
+type testResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// NewClient creates a new notifications client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		output:     os.Stdout,
+	}, nil
+}
+
+func (c *Client) prepareRequest(ctx context.Context, method string, endpoint string, params url.Values, body interface{}) (*http.Request, error) {
+	requestURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	requestURL.RawQuery = params.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		buffer := &bytes.Buffer{}
+		encoder := json.NewEncoder(buffer)
+		// Keeps the redacted settings of a dry run readable
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(body); err != nil {
+			return nil, fmt.Errorf("failed to marshal notification service: %w", err)
+		}
+		reader = buffer
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
+	request.Header.Add("Accept", "application/json")
+	if body != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+
+	return request, nil
+}
+
+func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+	if c.opts.Type != "" {
+		params.Add("type", c.opts.Type)
+	}
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/notificationservices")
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+func (c *Client) getServices(ctx context.Context, nextPage string) (*listServicesResponse, error) {
+	request, err := c.prepareListRequest(ctx, nextPage)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response listServicesResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (c *Client) getService(ctx context.Context, id string) (*NotificationService, error) {
+	request, err := shared.NewGetRequest(ctx, c.opts.BaseOptions, nil, "", "v1/notificationservices", id)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var service NotificationService
+	if err := json.Unmarshal(content, &service); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &service, nil
+}
+
+// newService builds the notification service described by the options. The
+// dedicated flags take precedence over generic --setting values.
+func (c *Client) newService() *NotificationService {
+	service := &NotificationService{
+		Type:        c.opts.Type,
+		Name:        c.opts.Name,
+		Description: c.opts.Description,
+		Settings:    make(map[string]interface{}),
+	}
+
+	for key, value := range c.opts.Settings {
+		service.Settings[key] = value
+	}
+
+	switch c.opts.Type {
+	case TypeEmail:
+		service.Settings["addresses"] = c.opts.Addresses
+	case TypeWebhook:
+		service.Settings["url"] = c.opts.URL
+		if c.opts.Secret != "" {
+			service.Settings["secret"] = c.opts.Secret
+		}
+	case TypeSlack:
+		service.Settings["url"] = c.opts.URL
+	case TypePagerDuty:
+		service.Settings["routingKey"] = c.opts.RoutingKey
+	}
+
+	return service
+}
+
+// redacted returns a copy of the service with sensitive settings hidden
+func redacted(service NotificationService) NotificationService {
+	if len(service.Settings) == 0 {
+		return service
+	}
+
+	settings := make(map[string]interface{}, len(service.Settings))
+	for key, value := range service.Settings {
+		if sensitiveSettings[strings.ToLower(key)] {
+			value = redactedValue
+		}
+		settings[key] = value
+	}
+	service.Settings = settings
+	return service
+}
+
+func (c *Client) printServices(services []NotificationService) error {
+	for _, service := range services {
+		if c.opts.JSON {
+			jsonData, err := json.Marshal(redacted(service))
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(c.output, string(jsonData))
+			continue
+		}
+
+		_, _ = fmt.Fprintf(c.output, "ID: %s, Type: %s, Name: %s", service.ID, service.Type, service.Name)
+		if service.Description != "" {
+			_, _ = fmt.Fprintf(c.output, ", Description: %s", service.Description)
+		}
+		_, _ = fmt.Fprintln(c.output)
+	}
+	return nil
+}
+
+func (c *Client) printService(service *NotificationService) error {
+	safe := redacted(*service)
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(safe)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(c.output, "ID: %s\n", safe.ID)
+	_, _ = fmt.Fprintf(c.output, "Type: %s\n", safe.Type)
+	_, _ = fmt.Fprintf(c.output, "Name: %s\n", safe.Name)
+	if safe.Description != "" {
+		_, _ = fmt.Fprintf(c.output, "Description: %s\n", safe.Description)
+	}
+	if safe.CreatedAt != "" {
+		_, _ = fmt.Fprintf(c.output, "CreatedAt: %s\n", safe.CreatedAt)
+	}
+
+	if len(safe.Settings) > 0 {
+		keys := make([]string, 0, len(safe.Settings))
+		for key := range safe.Settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		_, _ = fmt.Fprintf(c.output, "Settings:\n")
+		for _, key := range keys {
+			_, _ = fmt.Fprintf(c.output, "  %s: %s\n", key, formatSetting(safe.Settings[key]))
+		}
+	}
+
+	return nil
+}
+
+func formatSetting(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(jsonData)
+}
+
+// ListServices retrieves and displays notification services, optionally filtered by type
+func (c *Client) ListServices(ctx context.Context) error {
+	var nextPage string
+
+	for {
+		response, err := c.getServices(ctx, nextPage)
+		if err != nil {
+			return err
+		}
+
+		if err := c.printServices(response.Services); err != nil {
+			return fmt.Errorf("failed to print result: %w", err)
+		}
+
+		if response.NextPage == "" {
+			break
+		}
+		nextPage = response.NextPage
+	}
+
+	return nil
+}
+
+// GetService retrieves and displays a notification service with its settings
+func (c *Client) GetService(ctx context.Context) error {
+	service, err := c.getService(ctx, c.opts.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.printService(service)
+}
+
+// CreateService creates a notification service from the options
+func (c *Client) CreateService(ctx context.Context) error {
+	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/notificationservices")
+	if err != nil {
+		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	service := c.newService()
+	if c.opts.DryRun {
+		// The printed request must not reveal the secrets either
+		safe := redacted(*service)
+		service = &safe
+	}
+
+	request, err := c.prepareRequest(ctx, "POST", endpoint, url.Values{}, service)
+	if err != nil {
+		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	if c.opts.DryRun {
+		return shared.PrintDryRunRequest(c.output, request)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var response createServiceResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	if c.opts.JSON {
+		_, _ = fmt.Fprintln(c.output, string(content))
+		return nil
+	}
+	_, _ = fmt.Fprintf(c.output, "Notification service %s created\n", response.ID)
+	return nil
+}
+
+// TestService sends a test notification through the notification service
+func (c *Client) TestService(ctx context.Context) error {
+	endpoint, err := url.JoinPath(c.opts.APIURL, "v1/notificationservices", c.opts.ID, "test")
+	if err != nil {
+		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	request, err := c.prepareRequest(ctx, "POST", endpoint, url.Values{}, nil)
+	if err != nil {
+		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	if c.opts.DryRun {
+		return shared.PrintDryRunRequest(c.output, request)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil && !errors.Is(err, ErrNoContent) {
+		return err
+	}
+
+	// An empty response means the notification was accepted
+	response := testResponse{Success: true}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &response); err != nil {
+			return fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+		}
+	}
+
+	if !response.Success {
+		return fmt.Errorf("%w: %s", errTestFailed, response.Message)
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Test notification sent through %s\n", c.opts.ID)
+	return nil
+}
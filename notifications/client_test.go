@@ -0,0 +1,232 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var testServices = []NotificationService{
+	{ID: "n-1", Type: TypeSlack, Name: "Ops channel", Description: "#ops", Settings: map[string]interface{}{"url": "https://hooks.slack.com/services/T/B/X"}},
+	{ID: "n-2", Type: TypePagerDuty, Name: "On call", Settings: map[string]interface{}{"routingKey": "pd-secret"}, CreatedAt: "2024-05-13T10:00:00Z"},
+	{ID: "n-3", Type: TypeEmail, Name: "Team", Settings: map[string]interface{}{"addresses": []interface{}{"a@example.com", "b@example.com"}}},
+}
+
+// newServiceServer serves the test services, paging after the first one, and
+// records the created services and tested IDs
+func newServiceServer(t *testing.T) (*httptest.Server, *[]NotificationService, *[]string) {
+	var created []NotificationService
+	var tested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		path := strings.TrimPrefix(r.URL.Path, "/v1/notificationservices")
+		switch {
+		case r.Method == "GET" && path == "":
+			response := listServicesResponse{Services: testServices[:1]}
+			if r.URL.Query().Get("page") == "" {
+				require.Equal(t, "slack", r.URL.Query().Get("type"))
+				response.NextPage = "/v1/notificationservices?page=2"
+			} else {
+				response.Services = testServices[1:]
+			}
+			testutil.WriteJSON(t, w, response)
+		case r.Method == "GET":
+			for _, service := range testServices {
+				if "/"+service.ID == path {
+					testutil.WriteJSON(t, w, service)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "POST" && path == "":
+			var service NotificationService
+			if err := json.NewDecoder(r.Body).Decode(&service); err != nil {
+				t.Errorf("Failed to decode service: %v", err)
+			}
+			created = append(created, service)
+			testutil.WriteJSON(t, w, createServiceResponse{ID: "n-new"})
+		case r.Method == "POST" && strings.HasSuffix(path, "/test"):
+			id := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/test")
+			tested = append(tested, id)
+			switch id {
+			case "n-1":
+				w.WriteHeader(http.StatusNoContent)
+			case "n-2":
+				testutil.WriteJSON(t, w, testResponse{Success: true})
+			default:
+				testutil.WriteJSON(t, w, testResponse{Success: false, Message: "webhook returned 401"})
+			}
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &created, &tested
+}
+
+func TestListServices(t *testing.T) {
+	server, _, _ := newServiceServer(t)
+
+	opts := NewOptions()
+	opts.Type = TypeSlack
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.ListServices(context.Background()))
+	require.Equal(t, `ID: n-1, Type: slack, Name: Ops channel, Description: #ops
+ID: n-2, Type: pagerduty, Name: On call
+ID: n-3, Type: email, Name: Team
+`, testutil.ReadOutput(t, client.output))
+}
+
+func TestGetServiceRedactsSecrets(t *testing.T) {
+	server, _, _ := newServiceServer(t)
+
+	opts := NewOptions()
+	opts.ID = "n-2"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.GetService(context.Background()))
+	require.Equal(t, `ID: n-2
+Type: pagerduty
+Name: On call
+CreatedAt: 2024-05-13T10:00:00Z
+Settings:
+  routingKey: <redacted>
+`, testutil.ReadOutput(t, client.output))
+
+	opts.ID = "n-1"
+	client, err = NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.GetService(context.Background()))
+	require.Contains(t, testutil.ReadOutput(t, client.output), "  url: <redacted>\n")
+
+	opts.ID = "n-3"
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err = NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.GetService(context.Background()))
+	require.Equal(t, `{"id":"n-3","type":"email","title":"Team","settings":{"addresses":["a@example.com","b@example.com"]}}`+"\n",
+		testutil.ReadOutput(t, client.output))
+}
+
+func TestCreateService(t *testing.T) {
+	server, created, _ := newServiceServer(t)
+
+	opts := NewOptions()
+	opts.Type = TypeWebhook
+	opts.Name = "Deploy hook"
+	opts.URL = "https://example.com/hook"
+	opts.Secret = "s3cret"
+	opts.Settings = map[string]string{"method": "PUT", "url": "ignored"}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.CreateService(context.Background()))
+	require.Equal(t, []NotificationService{{
+		Type: TypeWebhook,
+		Name: "Deploy hook",
+		Settings: map[string]interface{}{
+			"url":    "https://example.com/hook",
+			"secret": "s3cret",
+			"method": "PUT",
+		},
+	}}, *created)
+	require.Equal(t, "Notification service n-new created\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestCreateServiceDryRun(t *testing.T) {
+	server, created, _ := newServiceServer(t)
+
+	opts := NewOptions()
+	opts.Type = TypeEmail
+	opts.Name = "Team"
+	opts.Addresses = []string{"a@example.com"}
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.CreateService(context.Background()))
+	require.Empty(t, *created)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.True(t, strings.HasPrefix(output, "POST "+server.URL+"/v1/notificationservices\n"))
+	require.Contains(t, output, `"addresses": [`)
+}
+
+func TestCreateServiceDryRunRedactsSecrets(t *testing.T) {
+	server, created, _ := newServiceServer(t)
+
+	opts := NewOptions()
+	opts.Type = TypeWebhook
+	opts.Name = "Deploy hook"
+	opts.URL = "https://example.com/hook?key=k3y"
+	opts.Secret = "s3cret"
+	opts.Settings = map[string]string{"method": "PUT", "Token": "t0ken", "Password": "passw0rd"}
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	require.NoError(t, client.CreateService(context.Background()))
+	require.Empty(t, *created)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, `"method": "PUT"`)
+	require.Contains(t, output, `"secret": "<redacted>"`)
+	require.Contains(t, output, `"url": "<redacted>"`)
+	require.Contains(t, output, `"Password": "<redacted>"`)
+	for _, secret := range []string{"k3y", "s3cret", "t0ken", "passw0rd"} {
+		require.NotContains(t, output, secret)
+	}
+}
+
+func TestTestService(t *testing.T) {
+	server, _, tested := newServiceServer(t)
+
+	opts := NewOptions()
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	opts.ID = "n-1"
+	require.NoError(t, client.TestService(context.Background()))
+	opts.ID = "n-2"
+	require.NoError(t, client.TestService(context.Background()))
+	opts.ID = "n-3"
+	err = client.TestService(context.Background())
+	require.ErrorIs(t, err, errTestFailed)
+	require.Contains(t, err.Error(), "webhook returned 401")
+
+	require.Equal(t, []string{"n-1", "n-2", "n-3"}, *tested)
+	require.Equal(t, "Test notification sent through n-1\nTest notification sent through n-2\n", testutil.ReadOutput(t, client.output))
+}
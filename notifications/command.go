@@ -0,0 +1,97 @@
+package notifications
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+// NewNotificationsCommand creates the notifications command
+func NewNotificationsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "notifications",
+		Usage: "Manage email, webhook, Slack and PagerDuty notification services",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List notification services",
+				Action: runList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Only list services of this type: email, webhook, slack or pagerduty",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "Show a notification service with its settings, secrets are redacted",
+				ArgsUsage: "ID",
+				Action:    runGet,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:   "create",
+				Usage:  "Create a notification service",
+				Action: runCreate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "type",
+						Usage:    "Type of the service: email, webhook, slack or pagerduty (required)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "name",
+						Aliases:  []string{"n"},
+						Usage:    "Name of the service (required)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "description",
+						Usage: "Description of the service",
+					},
+					&cli.StringSliceFlag{
+						Name:  "address",
+						Usage: "Email address to notify (email, can be specified multiple times)",
+					},
+					&cli.StringFlag{
+						Name:  "url",
+						Usage: "URL notifications are posted to (webhook and slack)",
+					},
+					&cli.StringFlag{
+						Name:  "secret",
+						Usage: "Secret used to sign webhook requests (webhook)",
+					},
+					&cli.StringFlag{
+						Name:  "routing-key",
+						Usage: "Integration key of the PagerDuty service (pagerduty)",
+					},
+					&cli.StringSliceFlag{
+						Name:  "setting",
+						Usage: "Additional setting in key=value format (can be specified multiple times)",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "test",
+				Usage:     "Send a test notification, e.g. after rotating a webhook secret",
+				ArgsUsage: "ID",
+				Action:    runTest,
+			},
+		},
+	}
+}
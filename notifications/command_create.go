@@ -0,0 +1,38 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func runCreate(ctx *cli.Context) error {
+	opts := NewOptions()
+	opts.Type = ctx.String("type")
+	opts.Name = ctx.String("name")
+	opts.Description = ctx.String("description")
+	opts.Addresses = ctx.StringSlice("address")
+	opts.URL = ctx.String("url")
+	opts.Secret = ctx.String("secret")
+	opts.RoutingKey = ctx.String("routing-key")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.ParseSettings(ctx.StringSlice("setting")); err != nil {
+		return err
+	}
+	if err := opts.ValidateForCreate(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.CreateService(context.Background())
+}
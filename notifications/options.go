@@ -0,0 +1,122 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// TypeEmail sends notifications to email addresses
+	TypeEmail = "email"
+	// TypeWebhook posts notifications to a URL
+	TypeWebhook = "webhook"
+	// TypeSlack posts notifications to a Slack incoming webhook
+	TypeSlack = "slack"
+	// TypePagerDuty triggers PagerDuty incidents through the Events API
+	TypePagerDuty = "pagerduty"
+)
+
+var (
+	errMissingID         = errors.New("notification service ID is required")
+	errMissingName       = errors.New("notification service name is required")
+	errInvalidType       = errors.New("invalid notification type, expected email, webhook, slack or pagerduty")
+	errMissingAddress    = errors.New("email notifications require at least one --address")
+	errMissingURL        = errors.New("webhook and slack notifications require --url")
+	errMissingRoutingKey = errors.New("pagerduty notifications require --routing-key")
+	errInvalidSetting    = errors.New("invalid setting format, expected key=value")
+
+	types = []string{TypeEmail, TypeWebhook, TypeSlack, TypePagerDuty}
+)
+
+// Options represents the command line options for the notifications command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	ID                 string
+	Type               string
+	Name               string
+	Description        string
+	Addresses          []string
+	URL                string
+	RoutingKey         string
+	Secret             string
+	Settings           map[string]string
+	JSON               bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{
+		Settings: make(map[string]string),
+	}
+}
+
+// ParseSettings parses additional settings in key=value format
+func (o *Options) ParseSettings(settingStrings []string) error {
+	o.Settings = make(map[string]string, len(settingStrings))
+	for _, settingStr := range settingStrings {
+		key, value, ok := strings.Cut(settingStr, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("%w: %s", errInvalidSetting, settingStr)
+		}
+		o.Settings[key] = strings.TrimSpace(value)
+	}
+	return nil
+}
+
+func validType(notificationType string) bool {
+	for _, t := range types {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateForList validates options for list operation
+func (o *Options) ValidateForList() error {
+	o.Type = strings.ToLower(strings.TrimSpace(o.Type))
+	if o.Type != "" && !validType(o.Type) {
+		return fmt.Errorf("%w: %s", errInvalidType, o.Type)
+	}
+	return nil
+}
+
+// ValidateForGet validates options for get and test operations
+func (o *Options) ValidateForGet() error {
+	if strings.TrimSpace(o.ID) == "" {
+		return errMissingID
+	}
+	return nil
+}
+
+// ValidateForCreate validates options for create operation, including the
+// settings every notification type requires
+func (o *Options) ValidateForCreate() error {
+	o.Type = strings.ToLower(strings.TrimSpace(o.Type))
+	if !validType(o.Type) {
+		return fmt.Errorf("%w: %s", errInvalidType, o.Type)
+	}
+	if strings.TrimSpace(o.Name) == "" {
+		return errMissingName
+	}
+
+	switch o.Type {
+	case TypeEmail:
+		if len(o.Addresses) == 0 {
+			return errMissingAddress
+		}
+	case TypeWebhook, TypeSlack:
+		if strings.TrimSpace(o.URL) == "" {
+			return errMissingURL
+		}
+	case TypePagerDuty:
+		if strings.TrimSpace(o.RoutingKey) == "" {
+			return errMissingRoutingKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateForCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr error
+	}{
+		{"email", Options{Type: "Email", Name: "Team", Addresses: []string{"a@example.com"}}, nil},
+		{"email without address", Options{Type: TypeEmail, Name: "Team"}, errMissingAddress},
+		{"webhook", Options{Type: TypeWebhook, Name: "Hook", URL: "https://example.com"}, nil},
+		{"slack without url", Options{Type: TypeSlack, Name: "Ops"}, errMissingURL},
+		{"pagerduty", Options{Type: TypePagerDuty, Name: "On call", RoutingKey: "key"}, nil},
+		{"pagerduty without key", Options{Type: TypePagerDuty, Name: "On call"}, errMissingRoutingKey},
+		{"missing name", Options{Type: TypeSlack, URL: "https://example.com"}, errMissingName},
+		{"unknown type", Options{Type: "sms", Name: "Phone"}, errInvalidType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.ValidateForCreate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateForList(t *testing.T) {
+	opts := NewOptions()
+	require.NoError(t, opts.ValidateForList())
+
+	opts.Type = "PagerDuty"
+	require.NoError(t, opts.ValidateForList())
+	require.Equal(t, TypePagerDuty, opts.Type)
+
+	opts.Type = "sms"
+	require.ErrorIs(t, opts.ValidateForList(), errInvalidType)
+
+	require.Equal(t, errMissingID, opts.ValidateForGet())
+}
+
+func TestParseSettings(t *testing.T) {
+	opts := NewOptions()
+	require.NoError(t, opts.ParseSettings([]string{"method=PUT", " channel = #ops "}))
+	require.Equal(t, map[string]string{"method": "PUT", "channel": "#ops"}, opts.Settings)
+
+	require.ErrorIs(t, opts.ParseSettings([]string{"method"}), errInvalidSetting)
+}
@@ -0,0 +1,362 @@
+// Package services provides a client for the health of APM services in the SWO
+// API. Services are entities, their health is summarized from the trace metrics.
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultPageSize for retrieving list of services and measurements
+	DefaultPageSize = 100
+
+	// EntityType is the entity type of APM services
+	EntityType = "Service"
+
+	// MetricRequests counts the requests served by a service
+	MetricRequests = "trace.service.requests"
+	// MetricErrors counts the requests that failed
+	MetricErrors = "trace.service.errors"
+	// MetricResponseTime is the response time of requests in milliseconds
+	MetricResponseTime = "trace.service.response_time"
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+
+	errServiceNotFound = errors.New("service not found")
+)
+
+// Client is a services client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	output     *os.File
+}
+
+// Service is an entity of the Service type
+type Service struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Stats summarize the requests of a service over a time window. Throughput is
+// in requests per minute, the error rate in percent and latencies in milliseconds.
+type Stats struct {
+	Requests   float64 `json:"requests"`
+	Errors     float64 `json:"errors"`
+	Throughput float64 `json:"throughput"`
+	ErrorRate  float64 `json:"errorRate"`
+	P50        float64 `json:"p50"`
+	P95        float64 `json:"p95"`
+	P99        float64 `json:"p99"`
+}
+
+// Health is the stats of a service in the window and in the window before
+type Health struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Window         timeWindow `json:"window"`
+	Current        Stats      `json:"current"`
+	PreviousWindow timeWindow `json:"previousWindow"`
+	Previous       Stats      `json:"previous"`
+}
+
+type point struct {
+	Value float64 `json:"value"`
+}
+
+type attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type series struct {
+	Attributes   []attribute `json:"attributes"`
+	Measurements []point     `json:"measurements"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type listServicesResponse struct {
+	Services []Service `json:"entities"`
+	pageInfo `json:"pageInfo"`
+}
+
+type measurementsResponse struct {
+	Groupings []series `json:"groupings"`
+	pageInfo  `json:"pageInfo"`
+}
+
+// measurement is a metric aggregation that fills one field of the stats
+type measurement struct {
+	metric      string
+	aggregation string
+	set         func(stats *Stats, value float64)
+}
+
+var measurements = []measurement{
+	{MetricRequests, "SUM", func(s *Stats, v float64) { s.Requests = v }},
+	{MetricErrors, "SUM", func(s *Stats, v float64) { s.Errors = v }},
+	{MetricResponseTime, "P50", func(s *Stats, v float64) { s.P50 = v }},
+	{MetricResponseTime, "P95", func(s *Stats, v float64) { s.P95 = v }},
+	{MetricResponseTime, "P99", func(s *Stats, v float64) { s.P99 = v }},
+}
+
+// NewClient creates a new services client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		output:     os.Stdout,
+	}, nil
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+// get sends a GET request for the endpoint path elements and unmarshals the response into v
+func (c *Client) get(ctx context.Context, params url.Values, nextPage string, v interface{}, elem ...string) error {
+	request, err := shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, elem...)
+	if err != nil {
+		return fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+	return nil
+}
+
+// listServices retrieves the services of all pages, optionally filtered by name
+func (c *Client) listServices(ctx context.Context, name string) ([]Service, error) {
+	params := url.Values{}
+	params.Add("type", EntityType)
+	if name != "" {
+		params.Add("name", name)
+	}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+
+	services := []Service{}
+	var nextPage string
+	for {
+		var response listServicesResponse
+		if err := c.get(ctx, params, nextPage, &response, "v1/entities"); err != nil {
+			return nil, err
+		}
+		services = append(services, response.Services...)
+
+		if response.NextPage == "" {
+			return services, nil
+		}
+		nextPage = response.NextPage
+	}
+}
+
+// measure aggregates a metric over the window per service ID, optionally for a
+// single service only
+func (c *Client) measure(ctx context.Context, m measurement, window timeWindow, serviceID string) (map[string]float64, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+	params.Add("aggregateBy", m.aggregation)
+	params.Add("seriesType", "SCALAR")
+	params.Add("startTime", window.Start.Format(time.RFC3339))
+	params.Add("endTime", window.End.Format(time.RFC3339))
+	params.Add("groupBy", "id")
+	if serviceID != "" {
+		params.Add("filter", fmt.Sprintf("id:%s", serviceID))
+	}
+
+	values := make(map[string]float64)
+	var nextPage string
+	for {
+		var response measurementsResponse
+		if err := c.get(ctx, params, nextPage, &response, "v1/metrics", m.metric, "measurements"); err != nil {
+			return nil, fmt.Errorf("failed to query %s %s: %w", strings.ToLower(m.aggregation), m.metric, err)
+		}
+
+		for _, s := range response.Groupings {
+			if len(s.Measurements) == 0 {
+				continue
+			}
+			for _, a := range s.Attributes {
+				if a.Key == "id" {
+					values[a.Value] = s.Measurements[len(s.Measurements)-1].Value
+				}
+			}
+		}
+
+		if response.NextPage == "" {
+			return values, nil
+		}
+		nextPage = response.NextPage
+	}
+}
+
+// collectStats summarizes every measurement over the window per service ID
+func (c *Client) collectStats(ctx context.Context, window timeWindow, serviceID string) (map[string]*Stats, error) {
+	stats := make(map[string]*Stats)
+	for _, m := range measurements {
+		values, err := c.measure(ctx, m, window, serviceID)
+		if err != nil {
+			return nil, err
+		}
+		for id, value := range values {
+			if stats[id] == nil {
+				stats[id] = &Stats{}
+			}
+			m.set(stats[id], value)
+		}
+	}
+
+	for _, s := range stats {
+		if minutes := window.Minutes(); minutes > 0 {
+			s.Throughput = s.Requests / minutes
+		}
+		if s.Requests > 0 {
+			s.ErrorRate = s.Errors / s.Requests * 100
+		}
+	}
+	return stats, nil
+}
+
+func serviceName(service Service) string {
+	if service.DisplayName != "" {
+		return service.DisplayName
+	}
+	return service.Name
+}
+
+// health compares the stats of the services in both windows
+func (c *Client) health(ctx context.Context, services []Service, serviceID string) ([]Health, error) {
+	current, err := c.collectStats(ctx, c.opts.window, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := c.collectStats(ctx, c.opts.previous, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Health, len(services))
+	for i, service := range services {
+		result[i] = Health{
+			ID:             service.ID,
+			Name:           serviceName(service),
+			Window:         c.opts.window,
+			PreviousWindow: c.opts.previous,
+		}
+		if s := current[service.ID]; s != nil {
+			result[i].Current = *s
+		}
+		if s := previous[service.ID]; s != nil {
+			result[i].Previous = *s
+		}
+	}
+	return result, nil
+}
+
+// ListServices displays the health of all services
+func (c *Client) ListServices(ctx context.Context) error {
+	services, err := c.listServices(ctx, "")
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		return serviceName(services[i]) < serviceName(services[j])
+	})
+
+	health, err := c.health(ctx, services, "")
+	if err != nil {
+		return err
+	}
+
+	return c.printHealthList(health)
+}
+
+// GetService displays the health of the service with the name
+func (c *Client) GetService(ctx context.Context) error {
+	services, err := c.listServices(ctx, c.opts.Name)
+	if err != nil {
+		return err
+	}
+
+	// The name filter of the API may match more than the exact name
+	var service *Service
+	for i := range services {
+		if services[i].Name == c.opts.Name || services[i].DisplayName == c.opts.Name {
+			service = &services[i]
+			break
+		}
+	}
+	if service == nil {
+		return fmt.Errorf("%w: %s", errServiceNotFound, c.opts.Name)
+	}
+
+	health, err := c.health(ctx, []Service{*service}, service.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.printHealth(health[0])
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNow = time.Date(2024, 5, 13, 12, 0, 0, 0, time.UTC)
+
+	testServices = []Service{
+		{ID: "e-2", Name: "checkout"},
+		{ID: "e-1", Name: "cart", DisplayName: "Cart API"},
+	}
+
+	// testValues are the measurements per window start, metric and aggregation.
+	// The current window starts at 11:00, the previous one at 10:00.
+	testValues = map[string]map[string]float64{
+		"2024-05-13T11:00:00Z": {
+			"e-1 trace.service.requests SUM":      6000,
+			"e-1 trace.service.errors SUM":        30,
+			"e-1 trace.service.response_time P50": 120,
+			"e-1 trace.service.response_time P95": 480,
+			"e-1 trace.service.response_time P99": 900,
+			"e-2 trace.service.requests SUM":      1200,
+			"e-2 trace.service.response_time P50": 250,
+			"e-2 trace.service.response_time P95": 600,
+			"e-2 trace.service.response_time P99": 1500,
+			"e-9 trace.service.requests SUM":      50,
+			"e-2 trace.service.errors SUM":        0,
+		},
+		"2024-05-13T10:00:00Z": {
+			"e-1 trace.service.requests SUM":      4800,
+			"e-1 trace.service.errors SUM":        6,
+			"e-1 trace.service.response_time P50": 100,
+			"e-1 trace.service.response_time P95": 400,
+			"e-1 trace.service.response_time P99": 1000,
+		},
+	}
+)
+
+func nextPage(r *http.Request, page int) string {
+	query := r.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	return r.URL.Path + "?" + query.Encode()
+}
+
+// newServicesServer serves the test services with one service per page and the
+// test measurements grouped by entity ID, one series per page
+func newServicesServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		require.Equal(t, "GET", r.Method)
+		query := r.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+
+		if r.URL.Path == "/v1/entities" {
+			require.Equal(t, EntityType, query.Get("type"))
+			services := testServices
+			if name := query.Get("name"); name != "" {
+				services = nil
+				for _, s := range testServices {
+					if strings.Contains(s.Name, name) {
+						services = append(services, s)
+					}
+				}
+			}
+			response := listServicesResponse{Services: []Service{}}
+			if page < len(services) {
+				response.Services = services[page : page+1]
+			}
+			if page+1 < len(services) {
+				response.NextPage = nextPage(r, page+1)
+			}
+			testutil.WriteJSON(t, w, response)
+			return
+		}
+
+		metric, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/v1/metrics/"), "/measurements")
+		require.True(t, ok, r.URL.Path)
+		require.Equal(t, "SCALAR", query.Get("seriesType"))
+		require.Equal(t, "id", query.Get("groupBy"))
+
+		var groupings []series
+		for _, id := range []string{"e-1", "e-2", "e-9"} {
+			if filter := query.Get("filter"); filter != "" && filter != "id:"+id {
+				continue
+			}
+			value, ok := testValues[query.Get("startTime")][id+" "+metric+" "+query.Get("aggregateBy")]
+			if !ok {
+				continue
+			}
+			groupings = append(groupings, series{
+				Attributes:   []attribute{{Key: "id", Value: id}},
+				Measurements: []point{{Value: value}},
+			})
+		}
+
+		response := measurementsResponse{}
+		if page < len(groupings) {
+			response.Groupings = groupings[page : page+1]
+		}
+		if page+1 < len(groupings) {
+			response.NextPage = nextPage(r, page+1)
+		}
+		testutil.WriteJSON(t, w, response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestOptions(t *testing.T) *Options {
+	opts := NewOptions()
+	opts.MinTime = "1 hour ago"
+	require.NoError(t, opts.Init(testNow))
+	return opts
+}
+
+func TestListServices(t *testing.T) {
+	server := newServicesServer(t)
+
+	opts := newTestOptions(t)
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.ListServices(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"NAME", "REQ/MIN", "ERROR", "RATE", "P50", "P95", "P99", "ID"}, strings.Fields(lines[0]))
+
+	// Sorted by name, the unknown entity e-9 is not listed
+	require.Equal(t, []string{"Cart", "API", "100.0", "(+25.0%)", "0.50%", "(+0.38pp)", "120ms", "(+20.0%)",
+		"480ms", "(+20.0%)", "900ms", "(-10.0%)", "e-1"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"checkout", "20.0", "(new)", "0.00%", "(±0pp)", "250ms", "(new)",
+		"600ms", "(new)", "1500ms", "(new)", "e-2"}, strings.Fields(lines[2]))
+}
+
+func TestGetService(t *testing.T) {
+	server := newServicesServer(t)
+
+	opts := newTestOptions(t)
+	opts.Name = "Cart API"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	// The API filters by entity name, the display name only matches here
+	require.ErrorIs(t, client.GetService(context.Background()), errServiceNotFound)
+
+	opts.Name = "cart"
+	require.NoError(t, client.GetService(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 10)
+	require.Equal(t, "Service: Cart API (e-1)", lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "Window: "))
+	require.True(t, strings.HasPrefix(lines[2], "Previous window: "))
+	require.Equal(t, []string{"CURRENT", "PREVIOUS", "CHANGE"}, strings.Fields(lines[3]))
+	require.Equal(t, []string{"Requests", "6000.0", "4800.0", "+25.0%"}, strings.Fields(lines[4]))
+	require.Equal(t, []string{"Throughput", "(req/min)", "100.0", "80.0", "+25.0%"}, strings.Fields(lines[5]))
+	require.Equal(t, []string{"Error", "rate", "0.50%", "0.12%", "+0.38pp"}, strings.Fields(lines[6]))
+	require.Equal(t, []string{"Latency", "p99", "900ms", "1000ms", "-10.0%"}, strings.Fields(lines[9]))
+}
+
+func TestGetServiceJSON(t *testing.T) {
+	server := newServicesServer(t)
+
+	opts := newTestOptions(t)
+	opts.Name = "checkout"
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.GetService(context.Background()))
+
+	var health Health
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &health))
+	require.Equal(t, "e-2", health.ID)
+	require.Equal(t, Stats{Requests: 1200, Throughput: 20, P50: 250, P95: 600, P99: 1500}, health.Current)
+	require.Equal(t, Stats{}, health.Previous)
+	require.Equal(t, testNow, health.Window.End)
+	require.Equal(t, testNow.Add(-2*time.Hour), health.PreviousWindow.Start)
+}
+
+func TestRelativeChange(t *testing.T) {
+	require.Equal(t, "+25.0%", relativeChange(125, 100))
+	require.Equal(t, "-50.0%", relativeChange(50, 100))
+	require.Equal(t, "±0%", relativeChange(100, 100))
+	require.Equal(t, "±0%", relativeChange(0, 0))
+	require.Equal(t, "new", relativeChange(5, 0))
+
+	require.Equal(t, "+0.38pp", pointsChange(0.5, 0.125))
+	require.Equal(t, "±0pp", pointsChange(0.5, 0.5))
+}
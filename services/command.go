@@ -0,0 +1,47 @@
+package services
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+func windowFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "min-time",
+			Usage: "Start of the window, it is compared with the window of the same length before it",
+			Value: "1 hour ago",
+		},
+		&cli.StringFlag{
+			Name:  "max-time",
+			Usage: "End of the window, defaults to now",
+		},
+		&cli.BoolFlag{
+			Name:    "json",
+			Aliases: []string{"j"},
+			Usage:   "Output in JSON format",
+		},
+	}
+}
+
+// NewServicesCommand creates the services command
+func NewServicesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "services",
+		Usage: "Show throughput, error rate and latency of APM services",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List services with their health compared with the previous window",
+				Action: runList,
+				Flags:  windowFlags(),
+			},
+			{
+				Name:      "get",
+				Usage:     "Show the health of a service compared with the previous window",
+				ArgsUsage: "NAME",
+				Action:    runGet,
+				Flags:     windowFlags(),
+			},
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+func runGet(ctx *cli.Context) error {
+	opts, err := newServiceOptions(ctx)
+	if err != nil {
+		return err
+	}
+	opts.Name = ctx.Args().First()
+	if err := opts.ValidateForGet(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.GetService(context.Background())
+}
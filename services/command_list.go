@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func newServiceOptions(ctx *cli.Context) (*Options, error) {
+	opts := NewOptions()
+	opts.MinTime = ctx.String("min-time")
+	opts.MaxTime = ctx.String("max-time")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+
+	if err := opts.Init(time.Now()); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func runList(ctx *cli.Context) error {
+	opts, err := newServiceOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.ListServices(context.Background())
+}
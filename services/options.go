@@ -0,0 +1,82 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+var (
+	errMissingName      = errors.New("service name is required")
+	errMinTimeFlag      = errors.New("failed to parse --min-time flag")
+	errMaxTimeFlag      = errors.New("failed to parse --max-time flag")
+	errInvalidTimeRange = errors.New("--min-time must be before --max-time")
+)
+
+// Options represents the command line options for the services command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	Name               string
+	MinTime            string
+	MaxTime            string
+	JSON               bool
+
+	// window is the time range of --min-time and --max-time, previous is the
+	// window of the same length right before it
+	window   timeWindow
+	previous timeWindow
+}
+
+// timeWindow is a time range metrics are summarized over
+type timeWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Minutes returns the length of the window in minutes
+func (w timeWindow) Minutes() float64 {
+	return w.End.Sub(w.Start).Minutes()
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{}
+}
+
+// Init parses the time flags relative to now into the compared windows. The
+// window ends now unless --max-time is given.
+func (o *Options) Init(now time.Time) error {
+	o.window = timeWindow{End: now.UTC().Truncate(time.Second)}
+
+	if o.MaxTime != "" {
+		maxTime, err := shared.ParseTime(o.MaxTime, now)
+		if err != nil {
+			return errors.Join(errMaxTimeFlag, err)
+		}
+		o.window.End = maxTime.UTC()
+	}
+
+	minTime, err := shared.ParseTime(o.MinTime, now)
+	if err != nil {
+		return errors.Join(errMinTimeFlag, err)
+	}
+	o.window.Start = minTime.UTC()
+
+	if !o.window.Start.Before(o.window.End) {
+		return errInvalidTimeRange
+	}
+
+	o.previous = timeWindow{Start: o.window.Start.Add(-o.window.End.Sub(o.window.Start)), End: o.window.Start}
+	return nil
+}
+
+// ValidateForGet validates options for get operation
+func (o *Options) ValidateForGet() error {
+	o.Name = strings.TrimSpace(o.Name)
+	if o.Name == "" {
+		return errMissingName
+	}
+	return nil
+}
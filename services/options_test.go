@@ -0,0 +1,42 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	now := time.Date(2024, 5, 13, 12, 0, 0, 0, time.UTC)
+
+	opts := NewOptions()
+	opts.MinTime = "1 hour ago"
+	require.NoError(t, opts.Init(now))
+	require.Equal(t, timeWindow{Start: now.Add(-time.Hour), End: now}, opts.window)
+	require.Equal(t, timeWindow{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)}, opts.previous)
+	require.Equal(t, float64(60), opts.window.Minutes())
+
+	opts = NewOptions()
+	opts.MinTime = "2024-05-13T09:00:00Z"
+	opts.MaxTime = "2024-05-13T09:30:00Z"
+	require.NoError(t, opts.Init(now))
+	require.Equal(t, now.Add(-3*time.Hour), opts.previous.End)
+	require.Equal(t, now.Add(-3*time.Hour-30*time.Minute), opts.previous.Start)
+
+	opts.MinTime = "what?"
+	require.ErrorIs(t, opts.Init(now), errMinTimeFlag)
+
+	opts.MinTime = "1 hour ago"
+	opts.MaxTime = "2 hours ago"
+	require.Equal(t, errInvalidTimeRange, opts.Init(now))
+}
+
+func TestValidateForGet(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingName, opts.ValidateForGet())
+
+	opts.Name = " checkout "
+	require.NoError(t, opts.ValidateForGet())
+	require.Equal(t, "checkout", opts.Name)
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', 1, 64)
+}
+
+func formatMillis(ms float64) string {
+	return strconv.FormatFloat(ms, 'f', 0, 64) + "ms"
+}
+
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64) + "%"
+}
+
+// relativeChange formats the change against the previous window in percent
+func relativeChange(current, previous float64) string {
+	switch {
+	case previous == 0 && current == 0:
+		return "±0%"
+	case previous == 0:
+		return "new"
+	}
+	change := (current - previous) / previous * 100
+	if math.Abs(change) < 0.05 {
+		return "±0%"
+	}
+	return fmt.Sprintf("%+.1f%%", change)
+}
+
+// pointsChange formats the change of a rate in percentage points, since a
+// relative change of a tiny error rate is mostly noise
+func pointsChange(current, previous float64) string {
+	change := current - previous
+	if math.Abs(change) < 0.005 {
+		return "±0pp"
+	}
+	return fmt.Sprintf("%+.2fpp", change)
+}
+
+func (c *Client) printJSON(v interface{}) error {
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to print result: %w", err)
+	}
+	_, _ = fmt.Fprintln(c.output, string(jsonData))
+	return nil
+}
+
+func (c *Client) printHealthList(services []Health) error {
+	if c.opts.JSON {
+		for _, health := range services {
+			if err := c.printJSON(health); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(c.output, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tREQ/MIN\tERROR RATE\tP50\tP95\tP99\tID")
+	for _, h := range services {
+		cur, prev := h.Current, h.Previous
+		_, _ = fmt.Fprintf(tw, "%s\t%s (%s)\t%s (%s)\t%s (%s)\t%s (%s)\t%s (%s)\t%s\n", h.Name,
+			formatNumber(cur.Throughput), relativeChange(cur.Throughput, prev.Throughput),
+			formatPercent(cur.ErrorRate), pointsChange(cur.ErrorRate, prev.ErrorRate),
+			formatMillis(cur.P50), relativeChange(cur.P50, prev.P50),
+			formatMillis(cur.P95), relativeChange(cur.P95, prev.P95),
+			formatMillis(cur.P99), relativeChange(cur.P99, prev.P99),
+			h.ID)
+	}
+	return tw.Flush()
+}
+
+func (c *Client) printHealth(h Health) error {
+	if c.opts.JSON {
+		return c.printJSON(h)
+	}
+
+	format := func(w timeWindow) string {
+		return w.Start.Local().Format(time.DateTime) + " - " + w.End.Local().Format(time.DateTime)
+	}
+	cur, prev := h.Current, h.Previous
+
+	_, _ = fmt.Fprintf(c.output, "Service: %s (%s)\n", h.Name, h.ID)
+	_, _ = fmt.Fprintf(c.output, "Window: %s\n", format(h.Window))
+	_, _ = fmt.Fprintf(c.output, "Previous window: %s\n", format(h.PreviousWindow))
+
+	tw := tabwriter.NewWriter(c.output, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "\tCURRENT\tPREVIOUS\tCHANGE")
+	_, _ = fmt.Fprintf(tw, "Requests\t%s\t%s\t%s\n",
+		formatNumber(cur.Requests), formatNumber(prev.Requests), relativeChange(cur.Requests, prev.Requests))
+	_, _ = fmt.Fprintf(tw, "Throughput (req/min)\t%s\t%s\t%s\n",
+		formatNumber(cur.Throughput), formatNumber(prev.Throughput), relativeChange(cur.Throughput, prev.Throughput))
+	_, _ = fmt.Fprintf(tw, "Error rate\t%s\t%s\t%s\n",
+		formatPercent(cur.ErrorRate), formatPercent(prev.ErrorRate), pointsChange(cur.ErrorRate, prev.ErrorRate))
+	_, _ = fmt.Fprintf(tw, "Latency p50\t%s\t%s\t%s\n",
+		formatMillis(cur.P50), formatMillis(prev.P50), relativeChange(cur.P50, prev.P50))
+	_, _ = fmt.Fprintf(tw, "Latency p95\t%s\t%s\t%s\n",
+		formatMillis(cur.P95), formatMillis(prev.P95), relativeChange(cur.P95, prev.P95))
+	_, _ = fmt.Fprintf(tw, "Latency p99\t%s\t%s\t%s\n",
+		formatMillis(cur.P99), formatMillis(prev.P99), relativeChange(cur.P99, prev.P99))
+	return tw.Flush()
+}
@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	colorReset = "\033[0m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+)
+
+// DiffOp is the kind of change of a single diff line
+type DiffOp byte
+
+const (
+	// DiffEqual marks a line present in both inputs
+	DiffEqual DiffOp = ' '
+	// DiffDelete marks a line present only in the first input
+	DiffDelete DiffOp = '-'
+	// DiffInsert marks a line present only in the second input
+	DiffInsert DiffOp = '+'
+)
+
+// DiffLine is a single line of a line-based diff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines computes a line-based diff between two inputs using the longest
+// common subsequence. Deleted lines are reported before inserted ones.
+func DiffLines(before, after []string) []DiffLine {
+	// lcs[i][j] holds the LCS length of before[i:] and after[j:]
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var result []DiffLine
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i] == after[j]:
+			result = append(result, DiffLine{Op: DiffEqual, Text: before[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, DiffLine{Op: DiffDelete, Text: before[i]})
+			i++
+		default:
+			result = append(result, DiffLine{Op: DiffInsert, Text: after[j]})
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		result = append(result, DiffLine{Op: DiffDelete, Text: before[i]})
+	}
+	for ; j < len(after); j++ {
+		result = append(result, DiffLine{Op: DiffInsert, Text: after[j]})
+	}
+
+	return result
+}
+
+// WriteDiff writes diff lines prefixed with their operation, using red and green
+// ANSI colors for deleted and inserted lines when color is set
+func WriteDiff(w io.Writer, lines []DiffLine, color bool) {
+	for _, line := range lines {
+		prefix, suffix := "", ""
+		if color {
+			switch line.Op {
+			case DiffDelete:
+				prefix, suffix = colorRed, colorReset
+			case DiffInsert:
+				prefix, suffix = colorGreen, colorReset
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s%c %s%s\n", prefix, line.Op, line.Text, suffix)
+	}
+}
@@ -0,0 +1,75 @@
+package shared
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		before   []string
+		after    []string
+		expected []DiffLine
+	}{
+		{
+			name:   "identical",
+			before: []string{"a", "b"},
+			after:  []string{"a", "b"},
+			expected: []DiffLine{
+				{Op: DiffEqual, Text: "a"},
+				{Op: DiffEqual, Text: "b"},
+			},
+		},
+		{
+			name:   "changed line",
+			before: []string{"{", `"env": "production"`, "}"},
+			after:  []string{"{", `"env": "staging"`, "}"},
+			expected: []DiffLine{
+				{Op: DiffEqual, Text: "{"},
+				{Op: DiffDelete, Text: `"env": "production"`},
+				{Op: DiffInsert, Text: `"env": "staging"`},
+				{Op: DiffEqual, Text: "}"},
+			},
+		},
+		{
+			name:   "added and removed lines",
+			before: []string{"a", "b", "c"},
+			after:  []string{"b", "c", "d"},
+			expected: []DiffLine{
+				{Op: DiffDelete, Text: "a"},
+				{Op: DiffEqual, Text: "b"},
+				{Op: DiffEqual, Text: "c"},
+				{Op: DiffInsert, Text: "d"},
+			},
+		},
+		{
+			name:     "empty inputs",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, DiffLines(tc.before, tc.after))
+		})
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	lines := []DiffLine{
+		{Op: DiffEqual, Text: "a"},
+		{Op: DiffDelete, Text: "b"},
+		{Op: DiffInsert, Text: "c"},
+	}
+
+	var plain bytes.Buffer
+	WriteDiff(&plain, lines, false)
+	require.Equal(t, "  a\n- b\n+ c\n", plain.String())
+
+	var colored bytes.Buffer
+	WriteDiff(&colored, lines, true)
+	require.Equal(t, "  a\n\033[31m- b\033[0m\n\033[32m+ c\033[0m\n", colored.String())
+}
@@ -0,0 +1,93 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+const redactedValue = "<redacted>"
+
+// sensitiveHeaders lists headers whose values are never printed
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"X-Api-Key":     true,
+}
+
+// PrintDryRunRequest writes the method, URL, headers and body of a request that
+// would have been sent. Sensitive header values are redacted and JSON bodies are
+// indented for readability. The request body stays readable for the caller.
+func PrintDryRunRequest(w io.Writer, req *http.Request) error {
+	_, _ = fmt.Fprintf(w, "%s %s\n", req.Method, req.URL.String())
+
+	keys := make([]string, 0, len(req.Header))
+	for key := range req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range req.Header[key] {
+			if sensitiveHeaders[http.CanonicalHeaderKey(key)] {
+				value = redactValue(value)
+			}
+			_, _ = fmt.Fprintf(w, "%s: %s\n", key, value)
+		}
+	}
+
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(content) == 0 {
+		return nil
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, content, "", "  "); err == nil {
+		content = indented.Bytes()
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%s\n", content)
+
+	return nil
+}
+
+// redactValue hides a header value while keeping the authentication scheme visible
+func redactValue(value string) string {
+	if scheme, _, ok := strings.Cut(value, " "); ok {
+		return scheme + " " + redactedValue
+	}
+	return redactedValue
+}
+
+// IsTerminal reports whether the file is an interactive terminal, which is
+// used to decide if ANSI colors should be written
+func IsTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
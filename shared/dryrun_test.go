@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintDryRunRequest(t *testing.T) {
+	request, err := http.NewRequest("PUT", "https://api.example.com/v1/entities/e-1", strings.NewReader(`{"id":"e-1","tags":{"env":"prod"}}`))
+	require.NoError(t, err)
+	request.Header.Add("Authorization", "Bearer secret-token")
+	request.Header.Add("Content-Type", "application/json")
+
+	var output bytes.Buffer
+	require.NoError(t, PrintDryRunRequest(&output, request))
+
+	expected := `PUT https://api.example.com/v1/entities/e-1
+Authorization: Bearer <redacted>
+Content-Type: application/json
+
+{
+  "id": "e-1",
+  "tags": {
+    "env": "prod"
+  }
+}
+`
+	require.Equal(t, expected, output.String())
+	require.NotContains(t, output.String(), "secret-token")
+
+	// The body must still be readable after printing
+	body, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"e-1","tags":{"env":"prod"}}`, string(body))
+}
+
+func TestPrintDryRunRequestWithoutBody(t *testing.T) {
+	request, err := http.NewRequest("DELETE", "https://api.example.com/v1/entities/e-1", nil)
+	require.NoError(t, err)
+	request.Header.Add("X-Api-Key", "secret")
+
+	var output bytes.Buffer
+	require.NoError(t, PrintDryRunRequest(&output, request))
+	require.Equal(t, "DELETE https://api.example.com/v1/entities/e-1\nX-Api-Key: <redacted>\n", output.String())
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "output"))
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	require.False(t, IsTerminal(f))
+	require.False(t, IsTerminal(nil))
+}
@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidDuration = errors.New("invalid duration")
+
+// ParseDuration parses durations like time.ParseDuration and additionally
+// accepts whole days and weeks with the "d" and "w" suffixes, e.g. "7d" or "2w"
+func ParseDuration(input string) (time.Duration, error) {
+	input = strings.TrimSpace(input)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if value, ok := strings.CutSuffix(input, suffix); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("%w: %s", errInvalidDuration, input)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", errInvalidDuration, input)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%w: %s", errInvalidDuration, input)
+	}
+	return d, nil
+}
+
+// FormatDuration formats durations in the form accepted by ParseDuration, using
+// days and weeks where possible and dropping zero minutes and seconds
+func FormatDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d == 0:
+		return "0s"
+	case d%(7*day) == 0:
+		return fmt.Sprintf("%dw", d/(7*day))
+	case d%day == 0:
+		return fmt.Sprintf("%dd", d/day)
+	}
+
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{input: "2h", expected: 2 * time.Hour},
+		{input: "1h30m", expected: 90 * time.Minute},
+		{input: "7d", expected: 7 * 24 * time.Hour},
+		{input: "2w", expected: 14 * 24 * time.Hour},
+		{input: " 30m ", expected: 30 * time.Minute},
+		{input: "1.5d", expectError: true},
+		{input: "-1h", expectError: true},
+		{input: "-1d", expectError: true},
+		{input: "soon", expectError: true},
+		{input: "", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			result, err := ParseDuration(tc.input)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                               "0s",
+		90 * time.Second:                "1m30s",
+		2 * time.Hour:                   "2h",
+		90 * time.Minute:                "1h30m",
+		24 * time.Hour:                  "1d",
+		14 * 24 * time.Hour:             "2w",
+		36 * time.Hour:                  "36h",
+		1500 * time.Millisecond:         "1.5s",
+		2*time.Hour + 5*time.Second:     "2h0m5s",
+		3*24*time.Hour + 30*time.Minute: "72h30m",
+	}
+
+	for d, expected := range tests {
+		require.Equal(t, expected, FormatDuration(d), d.String())
+
+		parsed, err := ParseDuration(expected)
+		require.NoError(t, err)
+		require.Equal(t, d, parsed)
+	}
+}
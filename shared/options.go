@@ -11,6 +11,7 @@ type BaseOptions struct {
 	Verbose bool   // Enable verbose output
 	Token   string // API token for authentication
 	APIURL  string // API URL for requests
+	DryRun  bool   // Print mutating requests instead of sending them
 }
 
 // SetupLogger configures the global slog logger based on verbose flag
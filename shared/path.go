@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"fmt"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// ExpandHome replaces a leading "~/" in the path with the home directory of the current user
+func ExpandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~/") {
+		return filepath.Clean(path), nil
+	}
+
+	usr, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("error while resolving current user: %w", err)
+	}
+
+	return filepath.Join(usr.HomeDir, path[2:]), nil
+}
@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"os/user"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandHome(t *testing.T) {
+	usr, err := user.Current()
+	require.NoError(t, err)
+
+	path, err := ExpandHome("~/.swo-cli.yml")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(usr.HomeDir, ".swo-cli.yml"), path)
+
+	path, err = ExpandHome("/tmp/../tmp/schedule.yml")
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/schedule.yml", path)
+}
@@ -0,0 +1,68 @@
+package shared
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Confirm asks the user to approve an action. Only "yes" is accepted as approval.
+func Confirm(in io.Reader, out io.Writer, prompt string) (bool, error) {
+	_, _ = fmt.Fprintf(out, "%s Only 'yes' will be accepted to approve.\nEnter a value: ", prompt)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	return strings.TrimSpace(answer) == "yes", nil
+}
+
+// ErrNotApproved indicates the changes were declined, or could not be confirmed
+// because there is no terminal to ask on
+var ErrNotApproved = errors.New("changes were not applied, use --auto-approve to apply them without confirmation")
+
+// Approve asks the user to approve changes. The prompt goes to prompts, usually
+// standard error, so it never mixes with the command output. ErrNotApproved is
+// returned unless the user approved.
+func Approve(in *os.File, prompts io.Writer, prompt string) error {
+	if !IsTerminal(in) {
+		return ErrNotApproved
+	}
+
+	approved, err := Confirm(in, prompts, prompt)
+	if err != nil {
+		return err
+	}
+	if !approved {
+		return ErrNotApproved
+	}
+	return nil
+}
+
+var errInvalidChoice = errors.New("invalid choice")
+
+// Choose asks the user to pick one of the options by its number and returns its index
+func Choose(in io.Reader, out io.Writer, prompt string, options []string) (int, error) {
+	_, _ = fmt.Fprintln(out, prompt)
+	for i, option := range options {
+		_, _ = fmt.Fprintf(out, "  %d) %s\n", i+1, option)
+	}
+	_, _ = fmt.Fprintf(out, "Enter a number [1-%d]: ", len(options))
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("failed to read choice: %w", err)
+	}
+
+	choice, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || choice < 1 || choice > len(options) {
+		return 0, fmt.Errorf("%w: %q", errInvalidChoice, strings.TrimSpace(answer))
+	}
+
+	return choice - 1, nil
+}
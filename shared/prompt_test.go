@@ -0,0 +1,65 @@
+package shared
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"yes", "yes\n", true},
+		{"yes with spaces", "  yes  \n", true},
+		{"yes without newline", "yes", true},
+		{"y is not enough", "y\n", false},
+		{"no", "no\n", false},
+		{"empty input", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var output bytes.Buffer
+			approved, err := Confirm(strings.NewReader(tc.input), &output, "Apply?")
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, approved)
+			require.Contains(t, output.String(), "Apply?")
+		})
+	}
+}
+
+func TestChoose(t *testing.T) {
+	options := []string{"first", "second", "third"}
+
+	var output bytes.Buffer
+	choice, err := Choose(strings.NewReader("2\n"), &output, "Pick one:", options)
+	require.NoError(t, err)
+	require.Equal(t, 1, choice)
+	require.Equal(t, "Pick one:\n  1) first\n  2) second\n  3) third\nEnter a number [1-3]: ", output.String())
+
+	for _, input := range []string{"0\n", "4\n", "two\n", ""} {
+		_, err := Choose(strings.NewReader(input), &output, "Pick one:", options)
+		require.ErrorIs(t, err, errInvalidChoice, input)
+	}
+}
+
+func TestApproveWithoutTerminal(t *testing.T) {
+	input, err := os.CreateTemp(t.TempDir(), "input")
+	require.NoError(t, err)
+	defer func() {
+		_ = input.Close()
+	}()
+	_, err = input.WriteString("yes\n")
+	require.NoError(t, err)
+
+	// A piped "yes" is not an approval
+	var prompts bytes.Buffer
+	require.ErrorIs(t, Approve(input, &prompts, "Apply?"), ErrNotApproved)
+	require.Empty(t, prompts.String())
+}
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// NewGetRequest builds an authenticated GET request for the endpoint path elements,
+// or for the next page returned by a previous response
+func NewGetRequest(ctx context.Context, opts BaseOptions, params url.Values, nextPage string, elem ...string) (*http.Request, error) {
+	var endpoint string
+	var err error
+	if nextPage == "" {
+		endpoint, err = url.JoinPath(opts.APIURL, elem...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		u, err := url.Parse(nextPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nextPage field: %w", err)
+		}
+
+		endpoint, err = url.JoinPath(opts.APIURL, u.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		params, err = url.ParseQuery(u.RawQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	requestURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	requestURL.RawQuery = params.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, "GET", requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", opts.Token))
+	request.Header.Add("Accept", "application/json")
+
+	return request, nil
+}
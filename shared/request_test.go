@@ -0,0 +1,33 @@
+package shared
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGetRequest(t *testing.T) {
+	opts := BaseOptions{Token: "test-token", APIURL: "https://api.example.com"}
+
+	request, err := NewGetRequest(context.Background(), opts, url.Values{"type": {"Service"}}, "", "v1/entities")
+	require.NoError(t, err)
+	require.Equal(t, "GET", request.Method)
+	require.Equal(t, "https://api.example.com/v1/entities?type=Service", request.URL.String())
+	require.Equal(t, "Bearer test-token", request.Header.Get("Authorization"))
+	require.Equal(t, "application/json", request.Header.Get("Accept"))
+
+	// Path elements are escaped
+	request, err = NewGetRequest(context.Background(), opts, nil, "", "v1/metrics", "requests?total", "measurements")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/v1/metrics/requests%3Ftotal/measurements", request.URL.String())
+
+	// The next page replaces the endpoint and the parameters
+	request, err = NewGetRequest(context.Background(), opts, url.Values{"type": {"Service"}}, "/v1/entities?skipToken=abc&type=Service", "v1/entities")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/v1/entities?skipToken=abc&type=Service", request.URL.String())
+
+	_, err = NewGetRequest(context.Background(), opts, nil, "://invalid", "v1/entities")
+	require.Error(t, err)
+}
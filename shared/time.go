@@ -0,0 +1,110 @@
+package shared
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/olebedev/when"
+)
+
+var (
+	// ErrInvalidDateTime indicates a timestamp could not be parsed
+	ErrInvalidDateTime = errors.New("could not parse timestamp")
+	// ErrMinTimeFlag indicates the --min-time flag could not be parsed
+	ErrMinTimeFlag = errors.New("failed to parse --min-time flag")
+	// ErrMaxTimeFlag indicates the --max-time flag could not be parsed
+	ErrMaxTimeFlag = errors.New("failed to parse --max-time flag")
+	// ErrInvalidTimeRange indicates --min-time is not before --max-time
+	ErrInvalidTimeRange = errors.New("--min-time must be before --max-time")
+
+	timeLayouts = []string{
+		time.Layout,
+		time.ANSIC,
+		time.UnixDate,
+		time.RubyDate,
+		time.RFC822,
+		time.RFC822Z,
+		time.RFC850,
+		time.RFC1123,
+		time.RFC1123Z,
+		time.RFC3339,
+		time.RFC3339Nano,
+		time.Kitchen,
+		time.Stamp,
+		time.StampMilli,
+		time.StampNano,
+		time.DateTime,
+		time.DateOnly,
+		time.TimeOnly,
+		"2006-01-02 15:04:05",
+	}
+)
+
+// ParseTime parses absolute timestamps in common layouts as well as natural
+// language expressions like "1 hour ago" or "tonight 23:00", which are relative
+// to now. Appending " UTC" interprets the input in UTC instead of local time.
+func ParseTime(input string, now time.Time) (time.Time, error) {
+	location := time.Local
+	if strings.HasSuffix(input, " UTC") {
+		l, err := time.LoadLocation("UTC")
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		location = l
+
+		input = strings.ReplaceAll(input, " UTC", "")
+	}
+
+	for _, layout := range timeLayouts {
+		result, err := time.Parse(layout, input)
+		if err == nil {
+			return result.In(location), nil
+		}
+	}
+
+	result, err := when.EN.Parse(input, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if result == nil {
+		return time.Time{}, ErrInvalidDateTime
+	}
+
+	return result.Time.In(location), nil
+}
+
+// ParseTimeRange parses the optional --min-time and --max-time flags relative to
+// now and formats them as RFC3339 in UTC. Empty flags stay empty.
+func ParseTimeRange(minTime, maxTime string, now time.Time) (string, string, error) {
+	var start, end time.Time
+	if minTime != "" {
+		t, err := ParseTime(minTime, now)
+		if err != nil {
+			return "", "", errors.Join(ErrMinTimeFlag, err)
+		}
+		start = t.UTC().Truncate(time.Second)
+	}
+
+	if maxTime != "" {
+		t, err := ParseTime(maxTime, now)
+		if err != nil {
+			return "", "", errors.Join(ErrMaxTimeFlag, err)
+		}
+		end = t.UTC().Truncate(time.Second)
+	}
+
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return "", "", ErrInvalidTimeRange
+	}
+
+	return formatRangeTime(start), formatRangeTime(end), nil
+}
+
+func formatRangeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	location, err := time.LoadLocation("GMT")
+	require.NoError(t, err)
+
+	time.Local = location
+
+	now, err := time.Parse(time.DateTime, "2000-01-01 10:00:30")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "RFC3339", input: "2000-01-01T12:13:14Z", expected: "2000-01-01T12:13:14Z"},
+		{name: "human readable", input: "5 seconds ago", expected: "2000-01-01T10:00:25Z"},
+		{name: "tonight", input: "tonight 23:00", expected: "2000-01-01T23:00:00Z"},
+		{name: "append UTC at the end", input: "2024-05-13 13:00:00 UTC", expected: "2024-05-13T13:00:00Z"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseTime(tc.input, now)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result.Format(time.RFC3339))
+		})
+	}
+
+	_, err = ParseTime("what?", now)
+	require.Error(t, err)
+}
+
+func TestParseTimeRange(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	minTime, maxTime, err := ParseTimeRange("", "", now)
+	require.NoError(t, err)
+	require.Empty(t, minTime)
+	require.Empty(t, maxTime)
+
+	minTime, maxTime, err = ParseTimeRange("2024-05-01T10:00:00+02:00", "2024-05-01T09:00:00Z", now)
+	require.NoError(t, err)
+	require.Equal(t, "2024-05-01T08:00:00Z", minTime)
+	require.Equal(t, "2024-05-01T09:00:00Z", maxTime)
+
+	_, _, err = ParseTimeRange("2024-05-01T11:00:00+02:00", "2024-05-01T09:00:00Z", now)
+	require.Equal(t, ErrInvalidTimeRange, err)
+
+	_, _, err = ParseTimeRange("what?", "", now)
+	require.ErrorIs(t, err, ErrMinTimeFlag)
+
+	_, _, err = ParseTimeRange("", "what?", now)
+	require.ErrorIs(t, err, ErrMaxTimeFlag)
+}
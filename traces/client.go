@@ -0,0 +1,136 @@
+// Package traces provides a client for retrieving distributed traces from the SWO API.
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+)
+
+// Client is a traces client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	output     *os.File
+}
+
+// Span is a single operation of a trace
+type Span struct {
+	ID           string    `json:"spanId"`
+	ParentID     string    `json:"parentSpanId,omitempty"`
+	Name         string    `json:"name"`
+	Service      string    `json:"serviceName"`
+	StartTime    time.Time `json:"startTime"`
+	Duration     float64   `json:"durationMs"`
+	Error        bool      `json:"error"`
+	ErrorMessage string    `json:"errorMessage,omitempty"`
+}
+
+// Trace is a distributed trace with all of its spans
+type Trace struct {
+	ID    string `json:"traceId"`
+	Spans []Span `json:"spans"`
+}
+
+// NewClient creates a new traces client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		output:     os.Stdout,
+	}, nil
+}
+
+func (c *Client) prepareGetRequest(ctx context.Context) (*http.Request, error) {
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, nil, "", "v1/traces", c.opts.TraceID)
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+func (c *Client) getTrace(ctx context.Context) (*Trace, error) {
+	request, err := c.prepareGetRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var trace Trace
+	if err := json.Unmarshal(content, &trace); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &trace, nil
+}
+
+// GetTrace retrieves a trace and displays its spans as a waterfall
+func (c *Client) GetTrace(ctx context.Context) error {
+	trace, err := c.getTrace(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(trace)
+		if err != nil {
+			return fmt.Errorf("failed to print result: %w", err)
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	return writeWaterfall(c.output, trace)
+}
@@ -0,0 +1,154 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var traceStart = time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC)
+
+func at(ms int) time.Time {
+	return traceStart.Add(time.Duration(ms) * time.Millisecond)
+}
+
+// testTrace is a checkout request fanning out to two services, listed out of order
+var testTrace = Trace{
+	ID: "4bf92f3577b34da6",
+	Spans: []Span{
+		{ID: "s-3", ParentID: "s-1", Name: "POST /payments", Service: "payments", StartTime: at(500), Duration: 480, Error: true, ErrorMessage: "card declined"},
+		{ID: "s-1", Name: "GET /checkout", Service: "frontend", StartTime: at(0), Duration: 1000},
+		{ID: "s-4", ParentID: "s-2", Name: "SELECT carts", Service: "cart", StartTime: at(120), Duration: 4.5},
+		{ID: "s-2", ParentID: "s-1", Name: "GET /cart", Service: "cart", StartTime: at(100), Duration: 300},
+	},
+}
+
+func newTraceServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		require.Equal(t, "GET", r.Method)
+
+		if r.URL.Path != "/v1/traces/"+testTrace.ID {
+			http.NotFound(w, r)
+			return
+		}
+		testutil.WriteJSON(t, w, testTrace)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetTrace(t *testing.T) {
+	server := newTraceServer(t)
+
+	opts := NewOptions()
+	opts.TraceID = testTrace.ID
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.GetTrace(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 7)
+	require.True(t, strings.HasPrefix(lines[0], "Trace: 4bf92f3577b34da6, Start: "))
+	require.True(t, strings.HasSuffix(lines[0], "Duration: 1.00s, Spans: 4, Services: 3, Errors: 1"))
+	require.Empty(t, lines[1])
+	require.Equal(t, []string{"TIMELINE", "DURATION", "SERVICE", "SPAN"}, strings.Fields(lines[2]))
+
+	// Children are indented below their parent in start order
+	require.Contains(t, lines[3], "1.00s")
+	require.Contains(t, lines[3], "frontend  GET /checkout")
+	require.Contains(t, lines[4], "300ms")
+	require.Contains(t, lines[4], "cart      ├─ GET /cart")
+	require.Contains(t, lines[5], "4.50ms")
+	require.Contains(t, lines[5], "cart      │  └─ SELECT carts")
+	require.Contains(t, lines[6], "payments  └─ POST /payments")
+	require.True(t, strings.HasSuffix(lines[6], "ERROR: card declined"))
+
+	// The root spans the whole timeline, the payment the second half
+	require.Contains(t, lines[3], "|"+strings.Repeat("█", timelineWidth)+"|")
+	require.Contains(t, lines[6], "|"+strings.Repeat(" ", 20)+strings.Repeat("█", 19)+" |")
+}
+
+func TestGetTraceJSON(t *testing.T) {
+	server := newTraceServer(t)
+
+	opts := NewOptions()
+	opts.TraceID = testTrace.ID
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.GetTrace(context.Background()))
+
+	var trace Trace
+	require.NoError(t, json.Unmarshal([]byte(testutil.ReadOutput(t, client.output)), &trace))
+	require.Equal(t, testTrace, trace)
+}
+
+func TestGetTraceNotFound(t *testing.T) {
+	server := newTraceServer(t)
+
+	opts := NewOptions()
+	opts.TraceID = "unknown"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.ErrorIs(t, client.GetTrace(context.Background()), ErrInvalidAPIResponse)
+}
+
+func TestSpanTreeOrphans(t *testing.T) {
+	spans := []Span{
+		{ID: "b", ParentID: "missing", StartTime: at(10)},
+		{ID: "a", StartTime: at(0)},
+		{ID: "c", ParentID: "c", StartTime: at(20)},
+	}
+
+	rows := spanTree(spans)
+	require.Len(t, rows, 3)
+	for i, id := range []string{"a", "b", "c"} {
+		require.Equal(t, id, rows[i].span.ID)
+		require.Empty(t, rows[i].prefix)
+	}
+}
+
+func TestSpanTreeCycle(t *testing.T) {
+	spans := []Span{
+		{ID: "root", StartTime: at(0)},
+		{ID: "b", ParentID: "a", StartTime: at(20)},
+		{ID: "a", ParentID: "b", StartTime: at(10)},
+	}
+
+	rows := spanTree(spans)
+	require.Len(t, rows, 3)
+	require.Equal(t, "root", rows[0].span.ID)
+	require.Equal(t, "a", rows[1].span.ID)
+	require.Empty(t, rows[1].prefix)
+	require.Equal(t, "b", rows[2].span.ID)
+	require.Equal(t, "└─ ", rows[2].prefix)
+}
+
+func TestWriteWaterfallEmpty(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, writeWaterfall(&out, &Trace{ID: "t-1"}))
+	require.Equal(t, "Trace t-1 has no spans\n", out.String())
+}
+
+func TestFormatMillis(t *testing.T) {
+	require.Equal(t, "0.42ms", formatMillis(0.42))
+	require.Equal(t, "320ms", formatMillis(320))
+	require.Equal(t, "1.24s", formatMillis(1240))
+}
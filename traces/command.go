@@ -0,0 +1,30 @@
+package traces
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+// NewTracesCommand creates the traces command
+func NewTracesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "traces",
+		Usage: "Inspect distributed traces",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "get",
+				Usage: "Show the spans of a trace as a waterfall with durations, services and errors",
+				ArgsUsage: `TRACE_ID
+
+   Use "swo logs get --trace TRACE_ID" for the logs correlated with the trace.`,
+				Action: runGet,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package traces
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+var errMissingTraceID = errors.New("trace ID is required")
+
+// Options represents the command line options for the traces command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	TraceID            string
+	JSON               bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{}
+}
+
+// ValidateForGet validates options for get operation
+func (o *Options) ValidateForGet() error {
+	o.TraceID = strings.TrimSpace(o.TraceID)
+	if o.TraceID == "" {
+		return errMissingTraceID
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package traces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateForGet(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingTraceID, opts.ValidateForGet())
+
+	opts.TraceID = " 4bf92f3577b34da6 "
+	require.NoError(t, opts.ValidateForGet())
+	require.Equal(t, "4bf92f3577b34da6", opts.TraceID)
+}
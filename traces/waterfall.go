@@ -0,0 +1,168 @@
+package traces
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// timelineWidth is the width of the bar column of the waterfall in terminal cells
+const timelineWidth = 40
+
+// row is a span placed in the tree, with its depth and tree drawing prefix
+type row struct {
+	span   Span
+	prefix string
+}
+
+// spanTree orders the spans depth first, children by start time. Spans whose
+// parent is not part of the trace are shown as roots, so a partially collected
+// trace still renders every span. Spans in a parent cycle are not reachable from
+// any root, the earliest of them is shown as a root as well.
+func spanTree(spans []Span) []row {
+	known := make(map[string]bool, len(spans))
+	for _, span := range spans {
+		known[span.ID] = true
+	}
+
+	children := make(map[string][]Span)
+	var roots []Span
+	for _, span := range spans {
+		if span.ParentID == "" || span.ParentID == span.ID || !known[span.ParentID] {
+			roots = append(roots, span)
+			continue
+		}
+		children[span.ParentID] = append(children[span.ParentID], span)
+	}
+
+	byStart := func(s []Span) {
+		sort.SliceStable(s, func(i, j int) bool {
+			return s[i].StartTime.Before(s[j].StartTime)
+		})
+	}
+	byStart(roots)
+
+	rows := make([]row, 0, len(spans))
+	visited := make(map[string]bool, len(spans))
+	var walk func(span Span, prefix, indent string)
+	walk = func(span Span, prefix, indent string) {
+		// Guard against malformed traces where spans are their own ancestors
+		if visited[span.ID] {
+			return
+		}
+		visited[span.ID] = true
+		rows = append(rows, row{span: span, prefix: prefix})
+
+		kids := children[span.ID]
+		byStart(kids)
+		for i, kid := range kids {
+			if i == len(kids)-1 {
+				walk(kid, indent+"└─ ", indent+"   ")
+			} else {
+				walk(kid, indent+"├─ ", indent+"│  ")
+			}
+		}
+	}
+	for _, root := range roots {
+		walk(root, "", "")
+	}
+
+	if len(visited) < len(spans) {
+		rest := append([]Span(nil), spans...)
+		byStart(rest)
+		for _, span := range rest {
+			if !visited[span.ID] {
+				walk(span, "", "")
+			}
+		}
+	}
+	return rows
+}
+
+// traceBounds returns the start and end of the trace over all spans
+func traceBounds(spans []Span) (time.Time, time.Time) {
+	var start, end time.Time
+	for i, span := range spans {
+		spanEnd := span.StartTime.Add(millis(span.Duration))
+		if i == 0 || span.StartTime.Before(start) {
+			start = span.StartTime
+		}
+		if i == 0 || spanEnd.After(end) {
+			end = spanEnd
+		}
+	}
+	return start, end
+}
+
+func millis(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// formatMillis formats a duration in milliseconds with a unit that fits its size
+func formatMillis(ms float64) string {
+	switch {
+	case ms >= 1000:
+		return strconv.FormatFloat(ms/1000, 'f', 2, 64) + "s"
+	case ms >= 10:
+		return strconv.FormatFloat(ms, 'f', 0, 64) + "ms"
+	default:
+		return strconv.FormatFloat(ms, 'f', 2, 64) + "ms"
+	}
+}
+
+// timeline draws a bar for the span positioned within the trace
+func timeline(span Span, start time.Time, total time.Duration) string {
+	offset, length := 0, timelineWidth
+	if total > 0 {
+		offset = int(math.Round(float64(span.StartTime.Sub(start)) / float64(total) * timelineWidth))
+		length = int(math.Round(float64(millis(span.Duration)) / float64(total) * timelineWidth))
+	}
+	offset = min(max(offset, 0), timelineWidth-1)
+	length = min(max(length, 1), timelineWidth-offset)
+
+	return "|" + strings.Repeat(" ", offset) + strings.Repeat("█", length) +
+		strings.Repeat(" ", timelineWidth-offset-length) + "|"
+}
+
+func writeWaterfall(w io.Writer, trace *Trace) error {
+	if len(trace.Spans) == 0 {
+		_, err := fmt.Fprintf(w, "Trace %s has no spans\n", trace.ID)
+		return err
+	}
+
+	start, end := traceBounds(trace.Spans)
+	total := end.Sub(start)
+
+	services := make(map[string]bool)
+	errorCount := 0
+	for _, span := range trace.Spans {
+		services[span.Service] = true
+		if span.Error {
+			errorCount++
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Trace: %s, Start: %s, Duration: %s, Spans: %d, Services: %d, Errors: %d\n\n",
+		trace.ID, start.Local().Format(time.DateTime), formatMillis(float64(total)/float64(time.Millisecond)),
+		len(trace.Spans), len(services), errorCount)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TIMELINE\tDURATION\tSERVICE\tSPAN\t")
+	for _, r := range spanTree(trace.Spans) {
+		flag := ""
+		if r.span.Error {
+			flag = "ERROR"
+			if r.span.ErrorMessage != "" {
+				flag += ": " + r.span.ErrorMessage
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", timeline(r.span, start, total),
+			formatMillis(r.span.Duration), r.span.Service, r.prefix+r.span.Name, flag)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,477 @@
+// Package uptime provides a client for managing HTTP, TCP and ping uptime checks
+// of Digital Experience Monitoring (DEM) in the SWO API.
+package uptime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultPageSize for retrieving list of uptime checks
+	DefaultPageSize = 100
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+)
+
+// Client is an uptime checks client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	input      *os.File
+	output     *os.File
+	prompts    *os.File
+}
+
+// Check is an uptime check run periodically from the probe locations
+type Check struct {
+	ID              string   `json:"id,omitempty"`
+	Type            string   `json:"type"`
+	Name            string   `json:"name"`
+	URL             string   `json:"url,omitempty"`
+	Host            string   `json:"host,omitempty"`
+	Port            int      `json:"port,omitempty"`
+	IntervalSeconds int      `json:"testIntervalInSeconds"`
+	Locations       []string `json:"locations,omitempty"`
+	Paused          bool     `json:"paused"`
+	Status          string   `json:"status,omitempty"`
+	LastCheckTime   string   `json:"lastCheckTime,omitempty"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type listChecksResponse struct {
+	Checks   []Check `json:"checks"`
+	pageInfo `json:"pageInfo"`
+}
+
+type createCheckResponse struct {
+	ID string `json:"id"`
+}
+
+// NewClient creates a new uptime checks client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		input:      os.Stdin,
+		output:     os.Stdout,
+		prompts:    os.Stderr,
+	}, nil
+}
+
+func (c *Client) prepareRequest(ctx context.Context, method string, params url.Values, body interface{}, elem ...string) (*http.Request, error) {
+	endpoint, err := url.JoinPath(c.opts.APIURL, append([]string{"v1/dem/checks"}, elem...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	requestURL.RawQuery = params.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal uptime check: %w", err)
+		}
+		reader = bytes.NewBuffer(jsonData)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.opts.Token))
+	request.Header.Add("Accept", "application/json")
+	if body != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+
+	return request, nil
+}
+
+func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+	if c.opts.Type != "" {
+		params.Add("type", c.opts.Type)
+	}
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/dem/checks")
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+// send sends a mutating request, or prints it in dry run mode. The response
+// body is returned, it is empty in dry run mode and for 204 responses.
+func (c *Client) send(ctx context.Context, method string, body interface{}, elem ...string) ([]byte, error) {
+	request, err := c.prepareRequest(ctx, method, url.Values{}, body, elem...)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	if c.opts.DryRun {
+		return nil, shared.PrintDryRunRequest(c.output, request)
+	}
+
+	content, err := c.doRequest(request)
+	if errors.Is(err, ErrNoContent) {
+		return nil, nil
+	}
+	return content, err
+}
+
+func (c *Client) getChecks(ctx context.Context, nextPage string) (*listChecksResponse, error) {
+	request, err := c.prepareListRequest(ctx, nextPage)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response listChecksResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (c *Client) getCheck(ctx context.Context, id string) (*Check, error) {
+	request, err := shared.NewGetRequest(ctx, c.opts.BaseOptions, nil, "", "v1/dem/checks", id)
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var check Check
+	if err := json.Unmarshal(content, &check); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &check, nil
+}
+
+// applySpec copies the fields set in the spec onto the check
+func applySpec(check *Check, spec *CheckSpec) error {
+	if spec.Name != "" {
+		check.Name = spec.Name
+	}
+	if spec.URL != "" {
+		check.URL = spec.URL
+	}
+	if spec.Host != "" {
+		check.Host = spec.Host
+	}
+	if spec.Port != 0 {
+		check.Port = spec.Port
+	}
+	if len(spec.Locations) > 0 {
+		check.Locations = spec.Locations
+	}
+
+	interval, err := spec.interval()
+	if err != nil {
+		return err
+	}
+	if interval > 0 {
+		check.IntervalSeconds = int(interval / time.Second)
+	}
+	return nil
+}
+
+// writable returns a copy of the check without the fields set by SWO, which are
+// not sent back on update
+func (check *Check) writable() *Check {
+	result := *check
+	result.ID, result.Status, result.LastCheckTime = "", "", ""
+	return &result
+}
+
+// target is what a check probes: the URL, host and port, or host
+func (check *Check) target() string {
+	switch {
+	case check.URL != "":
+		return check.URL
+	case check.Port != 0:
+		return check.Host + ":" + strconv.Itoa(check.Port)
+	default:
+		return check.Host
+	}
+}
+
+func (check *Check) state() string {
+	if check.Paused {
+		return "paused"
+	}
+	if check.Status == "" {
+		return "unknown"
+	}
+	return strings.ToLower(check.Status)
+}
+
+func (c *Client) printChecks(checks []Check) error {
+	for _, check := range checks {
+		if c.opts.JSON {
+			jsonData, err := json.Marshal(check)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(c.output, string(jsonData))
+			continue
+		}
+
+		_, _ = fmt.Fprintf(c.output, "ID: %s, Type: %s, Name: %s, Target: %s, Status: %s\n",
+			check.ID, check.Type, check.Name, check.target(), check.state())
+	}
+	return nil
+}
+
+func (c *Client) printCheck(check *Check) error {
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(check)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(c.output, string(jsonData))
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(c.output, "ID: %s\n", check.ID)
+	_, _ = fmt.Fprintf(c.output, "Type: %s\n", check.Type)
+	_, _ = fmt.Fprintf(c.output, "Name: %s\n", check.Name)
+	_, _ = fmt.Fprintf(c.output, "Target: %s\n", check.target())
+	_, _ = fmt.Fprintf(c.output, "Interval: %s\n", shared.FormatDuration(time.Duration(check.IntervalSeconds)*time.Second))
+	if len(check.Locations) > 0 {
+		_, _ = fmt.Fprintf(c.output, "Locations: %s\n", strings.Join(check.Locations, ", "))
+	}
+	_, _ = fmt.Fprintf(c.output, "Status: %s\n", check.state())
+	if check.LastCheckTime != "" {
+		_, _ = fmt.Fprintf(c.output, "LastCheckTime: %s\n", check.LastCheckTime)
+	}
+	return nil
+}
+
+// ListChecks retrieves and displays uptime checks, optionally filtered by type
+func (c *Client) ListChecks(ctx context.Context) error {
+	var nextPage string
+
+	for {
+		response, err := c.getChecks(ctx, nextPage)
+		if err != nil {
+			return err
+		}
+
+		if err := c.printChecks(response.Checks); err != nil {
+			return fmt.Errorf("failed to print result: %w", err)
+		}
+
+		if response.NextPage == "" {
+			break
+		}
+		nextPage = response.NextPage
+	}
+
+	return nil
+}
+
+// findCheck returns the check with the name and type, or nil if there is none
+func (c *Client) findCheck(ctx context.Context, name string, checkType string) (*Check, error) {
+	var nextPage string
+	for {
+		response, err := c.getChecks(ctx, nextPage)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range response.Checks {
+			if response.Checks[i].Name == name && response.Checks[i].Type == checkType {
+				return &response.Checks[i], nil
+			}
+		}
+
+		if response.NextPage == "" {
+			return nil, nil
+		}
+		nextPage = response.NextPage
+	}
+}
+
+// GetCheck retrieves and displays an uptime check
+func (c *Client) GetCheck(ctx context.Context) error {
+	check, err := c.getCheck(ctx, c.opts.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.printCheck(check)
+}
+
+// CreateCheck creates an uptime check from the spec. With --if-not-exists an
+// existing check of the same name and type is kept instead.
+func (c *Client) CreateCheck(ctx context.Context) error {
+	if c.opts.IfNotExists {
+		existing, err := c.findCheck(ctx, c.opts.Spec.Name, c.opts.Spec.Type)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if c.opts.JSON {
+				return c.printCheck(existing)
+			}
+			_, _ = fmt.Fprintf(c.output, "Uptime check %s already exists\n", existing.ID)
+			return nil
+		}
+	}
+
+	check := &Check{Type: c.opts.Spec.Type, IntervalSeconds: int(DefaultInterval / time.Second)}
+	if err := applySpec(check, &c.opts.Spec); err != nil {
+		return err
+	}
+
+	content, err := c.send(ctx, "POST", check)
+	if err != nil || c.opts.DryRun {
+		return err
+	}
+
+	var response createCheckResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	if c.opts.JSON {
+		check.ID = response.ID
+		return c.printCheck(check)
+	}
+	_, _ = fmt.Fprintf(c.output, "Uptime check %s created\n", response.ID)
+	return nil
+}
+
+// UpdateCheck changes the fields set in the spec and keeps all others
+func (c *Client) UpdateCheck(ctx context.Context) error {
+	check, err := c.getCheck(ctx, c.opts.ID)
+	if err != nil {
+		return err
+	}
+
+	if spec := strings.ToLower(c.opts.Spec.Type); spec != "" && spec != check.Type {
+		return fmt.Errorf("%w: %s is a %s check", errTypeChange, check.ID, check.Type)
+	}
+	if err := c.opts.Spec.validateForType(check.Type); err != nil {
+		return err
+	}
+	if err := applySpec(check, &c.opts.Spec); err != nil {
+		return err
+	}
+
+	if _, err := c.send(ctx, "PUT", check.writable(), c.opts.ID); err != nil || c.opts.DryRun {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Uptime check %s updated\n", c.opts.ID)
+	return nil
+}
+
+// DeleteCheck deletes an uptime check after confirmation
+func (c *Client) DeleteCheck(ctx context.Context) error {
+	if !c.opts.AutoApprove && !c.opts.DryRun {
+		if err := shared.Approve(c.input, c.prompts, fmt.Sprintf("Do you want to delete uptime check %s?", c.opts.ID)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := c.send(ctx, "DELETE", nil, c.opts.ID); err != nil || c.opts.DryRun {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Uptime check %s deleted\n", c.opts.ID)
+	return nil
+}
+
+// PauseCheck stops running an uptime check until it is resumed
+func (c *Client) PauseCheck(ctx context.Context) error {
+	if _, err := c.send(ctx, "POST", nil, c.opts.ID, "pause"); err != nil || c.opts.DryRun {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Uptime check %s paused\n", c.opts.ID)
+	return nil
+}
+
+// ResumeCheck runs a paused uptime check again
+func (c *Client) ResumeCheck(ctx context.Context) error {
+	if _, err := c.send(ctx, "POST", nil, c.opts.ID, "resume"); err != nil || c.opts.DryRun {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.output, "Uptime check %s resumed\n", c.opts.ID)
+	return nil
+}
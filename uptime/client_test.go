@@ -0,0 +1,272 @@
+package uptime
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+// checkStore is the state of the fake uptime API
+type checkStore struct {
+	checks   map[string]*Check
+	requests []string
+	bodies   []Check
+	raw      []string
+}
+
+func newCheckServer(t *testing.T) (*httptest.Server, *checkStore) {
+	store := &checkStore{checks: map[string]*Check{
+		"c-1": {ID: "c-1", Type: TypeHTTP, Name: "api", URL: "https://api.example.com/health", IntervalSeconds: 300, Locations: []string{"na", "emea"}, Status: "UP", LastCheckTime: "2024-05-13T10:00:00Z"},
+		"c-2": {ID: "c-2", Type: TypeTCP, Name: "db", Host: "db.example.com", Port: 5432, IntervalSeconds: 60, Paused: true},
+		"c-3": {ID: "c-3", Type: TypePing, Name: "gateway", Host: "10.0.0.1", IntervalSeconds: 900, Status: "DOWN"},
+	}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		path := strings.TrimPrefix(r.URL.Path, "/v1/dem/checks")
+		if r.Method != "GET" {
+			store.requests = append(store.requests, r.Method+" "+path)
+		}
+		if r.Method == "POST" || r.Method == "PUT" {
+			body, _ := io.ReadAll(r.Body)
+			var check Check
+			if err := json.Unmarshal(body, &check); err == nil {
+				store.bodies = append(store.bodies, check)
+				store.raw = append(store.raw, string(body))
+			}
+		}
+
+		switch {
+		case r.Method == "GET" && path == "":
+			response := listChecksResponse{}
+			if r.URL.Query().Get("page") == "" {
+				require.Equal(t, "100", r.URL.Query().Get("pageSize"))
+				response.Checks = []Check{*store.checks["c-1"]}
+				response.NextPage = "/v1/dem/checks?page=2"
+			} else {
+				response.Checks = []Check{*store.checks["c-2"], *store.checks["c-3"]}
+			}
+			testutil.WriteJSON(t, w, response)
+		case r.Method == "GET":
+			check, ok := store.checks[strings.TrimPrefix(path, "/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			testutil.WriteJSON(t, w, check)
+		case r.Method == "POST" && path == "":
+			testutil.WriteJSON(t, w, createCheckResponse{ID: "c-new"})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, store
+}
+
+func TestListChecks(t *testing.T) {
+	server, _ := newCheckServer(t)
+
+	opts := NewOptions()
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+	require.NoError(t, client.ListChecks(context.Background()))
+	require.Equal(t, `ID: c-1, Type: http, Name: api, Target: https://api.example.com/health, Status: up
+ID: c-2, Type: tcp, Name: db, Target: db.example.com:5432, Status: paused
+ID: c-3, Type: ping, Name: gateway, Target: 10.0.0.1, Status: down
+`, testutil.ReadOutput(t, client.output))
+}
+
+func TestGetCheck(t *testing.T) {
+	server, _ := newCheckServer(t)
+
+	opts := NewOptions()
+	opts.ID = "c-1"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.GetCheck(context.Background()))
+	require.Equal(t, `ID: c-1
+Type: http
+Name: api
+Target: https://api.example.com/health
+Interval: 5m
+Locations: na, emea
+Status: up
+LastCheckTime: 2024-05-13T10:00:00Z
+`, testutil.ReadOutput(t, client.output))
+}
+
+func TestCreateCheck(t *testing.T) {
+	server, store := newCheckServer(t)
+
+	opts := NewOptions()
+	opts.Spec = CheckSpec{Type: TypeTCP, Name: "cache", Host: "cache.example.com", Port: 6379, Locations: []string{"emea"}}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.CreateCheck(context.Background()))
+	require.Equal(t, []string{"POST "}, store.requests)
+	require.Equal(t, []Check{{Type: TypeTCP, Name: "cache", Host: "cache.example.com", Port: 6379, IntervalSeconds: 300, Locations: []string{"emea"}}}, store.bodies)
+	require.Equal(t, "Uptime check c-new created\n", testutil.ReadOutput(t, client.output))
+}
+
+func TestUpdateCheck(t *testing.T) {
+	server, store := newCheckServer(t)
+
+	opts := NewOptions()
+	opts.ID = "c-1"
+	opts.Spec = CheckSpec{Interval: "1m", Locations: []string{"apac"}}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.UpdateCheck(context.Background()))
+	require.Equal(t, []string{"PUT /c-1"}, store.requests)
+
+	updated := store.bodies[0]
+	require.Equal(t, "api", updated.Name)
+	require.Equal(t, "https://api.example.com/health", updated.URL)
+	require.Equal(t, 60, updated.IntervalSeconds)
+	require.Equal(t, []string{"apac"}, updated.Locations)
+	require.Equal(t, "Uptime check c-1 updated\n", testutil.ReadOutput(t, client.output))
+
+	// Fields set by SWO are not sent back
+	require.NotContains(t, store.raw[0], `"status"`)
+	require.NotContains(t, store.raw[0], `"lastCheckTime"`)
+	require.NotContains(t, store.raw[0], `"id"`)
+
+	opts.Spec = CheckSpec{Type: TypePing, Name: "renamed"}
+	require.ErrorIs(t, client.UpdateCheck(context.Background()), errTypeChange)
+}
+
+func TestUpdateCheckFieldsForType(t *testing.T) {
+	server, store := newCheckServer(t)
+
+	opts := NewOptions()
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	opts.ID = "c-2"
+	opts.Spec = CheckSpec{URL: "https://db.example.com"}
+	require.ErrorIs(t, client.UpdateCheck(context.Background()), errFieldForType)
+
+	opts.ID = "c-1"
+	opts.Spec = CheckSpec{Port: 8080}
+	require.ErrorIs(t, client.UpdateCheck(context.Background()), errFieldForType)
+
+	opts.ID = "c-3"
+	opts.Spec = CheckSpec{Port: 22}
+	require.ErrorIs(t, client.UpdateCheck(context.Background()), errFieldForType)
+
+	require.Empty(t, store.requests)
+}
+
+func TestCreateCheckIfNotExists(t *testing.T) {
+	server, store := newCheckServer(t)
+
+	opts := NewOptions()
+	opts.IfNotExists = true
+	opts.Spec = CheckSpec{Type: TypeTCP, Name: "db", Host: "db.example.com", Port: 5432}
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.CreateCheck(context.Background()))
+	require.Empty(t, store.requests)
+	require.Equal(t, "Uptime check c-2 already exists\n", testutil.ReadOutput(t, client.output))
+
+	// A check of the same name but another type does not match
+	opts.Spec = CheckSpec{Type: TypePing, Name: "db", Host: "db.example.com"}
+	require.NoError(t, client.CreateCheck(context.Background()))
+	require.Equal(t, []string{"POST "}, store.requests)
+}
+
+func TestCheckActions(t *testing.T) {
+	server, store := newCheckServer(t)
+
+	opts := NewOptions()
+	opts.ID = "c-2"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.PauseCheck(context.Background()))
+	require.NoError(t, client.ResumeCheck(context.Background()))
+
+	// Deleting requires approval
+	require.ErrorIs(t, client.DeleteCheck(context.Background()), shared.ErrNotApproved)
+	opts.AutoApprove = true
+	require.NoError(t, client.DeleteCheck(context.Background()))
+
+	require.Equal(t, []string{"POST /c-2/pause", "POST /c-2/resume", "DELETE /c-2"}, store.requests)
+	require.Equal(t, `Uptime check c-2 paused
+Uptime check c-2 resumed
+Uptime check c-2 deleted
+`, testutil.ReadOutput(t, client.output))
+}
+
+func TestCheckDryRun(t *testing.T) {
+	server, store := newCheckServer(t)
+
+	opts := NewOptions()
+	opts.ID = "c-3"
+	opts.DryRun = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	client.input = testutil.TempFile(t)
+	client.prompts = testutil.TempFile(t)
+
+	require.NoError(t, client.DeleteCheck(context.Background()))
+	require.NoError(t, client.PauseCheck(context.Background()))
+	require.Empty(t, store.requests)
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "DELETE "+server.URL+"/v1/dem/checks/c-3\n")
+	require.Contains(t, output, "POST "+server.URL+"/v1/dem/checks/c-3/pause\n")
+}
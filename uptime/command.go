@@ -0,0 +1,115 @@
+package uptime
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+func specFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "YAML spec of the check, flags take precedence over the spec",
+		},
+		&cli.StringFlag{
+			Name:    "name",
+			Aliases: []string{"n"},
+			Usage:   "Name of the check",
+		},
+		&cli.StringFlag{
+			Name:  "url",
+			Usage: "URL probed by http checks",
+		},
+		&cli.StringFlag{
+			Name:  "host",
+			Usage: "Host probed by tcp and ping checks",
+		},
+		&cli.IntFlag{
+			Name:  "port",
+			Usage: "Port probed by tcp checks",
+		},
+		&cli.StringFlag{
+			Name:  "interval",
+			Usage: "How often the check runs, e.g. 1m or 15m (default 5m)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "location",
+			Usage: "Probe location the check runs from (can be specified multiple times)",
+		},
+	}
+}
+
+func idCommand(name string, usage string, action cli.ActionFunc, flags ...cli.Flag) *cli.Command {
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		ArgsUsage: "ID",
+		Action:    action,
+		Flags:     flags,
+	}
+}
+
+// NewUptimeCommand creates the uptime command
+func NewUptimeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "uptime",
+		Usage: "Manage HTTP, TCP and ping uptime checks",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List uptime checks",
+				Action: runList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Only list checks of this type: http, tcp or ping",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				},
+			},
+			idCommand("get", "Show an uptime check", runGet, &cli.BoolFlag{
+				Name:    "json",
+				Aliases: []string{"j"},
+				Usage:   "Output in JSON format",
+			}),
+			{
+				Name:  "create",
+				Usage: "Create an uptime check from flags or a YAML spec",
+				Description: `The spec file has the same fields as the flags, e.g.
+
+   name: api health
+   type: http
+   url: https://api.example.com/health
+   interval: 5m
+   locations: [na, emea]`,
+				Action: runCreate,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Type of the check: http, tcp or ping",
+					},
+					&cli.BoolFlag{
+						Name:  "if-not-exists",
+						Usage: "Do nothing if a check of the same name and type exists, so create can be run repeatedly",
+					},
+					&cli.BoolFlag{
+						Name:    "json",
+						Aliases: []string{"j"},
+						Usage:   "Output in JSON format",
+					},
+				}, specFlags()...),
+			},
+			idCommand("update", "Change the fields given as flags or in a YAML spec, all others are kept", runUpdate, specFlags()...),
+			idCommand("delete", "Delete an uptime check", runDelete, &cli.BoolFlag{
+				Name:  "auto-approve",
+				Usage: "Delete the check without asking for confirmation",
+			}),
+			idCommand("pause", "Stop running an uptime check", runPause),
+			idCommand("resume", "Run a paused uptime check again", runResume),
+		},
+	}
+}
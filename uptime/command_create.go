@@ -0,0 +1,80 @@
+package uptime
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/shared"
+	cli "github.com/urfave/cli/v2"
+)
+
+// setSpec reads the spec file, if any, and overrides it with the flags that were set
+func setSpec(ctx *cli.Context, opts *Options) error {
+	if path := ctx.String("file"); path != "" {
+		path, err := shared.ExpandHome(path)
+		if err != nil {
+			return err
+		}
+		if err := opts.LoadSpec(path); err != nil {
+			return err
+		}
+	}
+
+	spec := &opts.Spec
+	if ctx.IsSet("type") {
+		spec.Type = ctx.String("type")
+	}
+	if ctx.IsSet("name") {
+		spec.Name = ctx.String("name")
+	}
+	if ctx.IsSet("url") {
+		spec.URL = ctx.String("url")
+	}
+	if ctx.IsSet("host") {
+		spec.Host = ctx.String("host")
+	}
+	if ctx.IsSet("port") {
+		spec.Port = ctx.Int("port")
+	}
+	if ctx.IsSet("interval") {
+		spec.Interval = ctx.String("interval")
+	}
+	if ctx.IsSet("location") {
+		spec.Locations = ctx.StringSlice("location")
+	}
+	return nil
+}
+
+func runCreate(ctx *cli.Context) error {
+	opts := newCheckOptions(ctx)
+	opts.IfNotExists = ctx.Bool("if-not-exists")
+	if err := setSpec(ctx, opts); err != nil {
+		return err
+	}
+	if err := opts.ValidateForCreate(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.CreateCheck(context.Background())
+}
+
+func runUpdate(ctx *cli.Context) error {
+	opts := newCheckOptions(ctx)
+	if err := setSpec(ctx, opts); err != nil {
+		return err
+	}
+	if err := opts.ValidateForUpdate(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return client.UpdateCheck(context.Background())
+}
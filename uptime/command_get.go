@@ -0,0 +1,51 @@
+package uptime
+
+import (
+	"context"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func newCheckOptions(ctx *cli.Context) *Options {
+	opts := NewOptions()
+	opts.ID = ctx.Args().First()
+	opts.JSON = ctx.Bool("json")
+	opts.AutoApprove = ctx.Bool("auto-approve")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.DryRun = ctx.Bool(config.DryRunContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+	return opts
+}
+
+// runByID validates the ID argument and runs the client method
+func runByID(ctx *cli.Context, run func(*Client, context.Context) error) error {
+	opts := newCheckOptions(ctx)
+	if err := opts.ValidateForGet(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	return run(client, context.Background())
+}
+
+func runGet(ctx *cli.Context) error {
+	return runByID(ctx, (*Client).GetCheck)
+}
+
+func runDelete(ctx *cli.Context) error {
+	return runByID(ctx, (*Client).DeleteCheck)
+}
+
+func runPause(ctx *cli.Context) error {
+	return runByID(ctx, (*Client).PauseCheck)
+}
+
+func runResume(ctx *cli.Context) error {
+	return runByID(ctx, (*Client).ResumeCheck)
+}
@@ -0,0 +1,204 @@
+package uptime
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	// TypeHTTP checks that a URL responds successfully
+	TypeHTTP = "http"
+	// TypeTCP checks that a TCP port accepts connections
+	TypeTCP = "tcp"
+	// TypePing checks that a host answers ICMP echo requests
+	TypePing = "ping"
+
+	// DefaultInterval is how often new checks are run
+	DefaultInterval = 5 * time.Minute
+)
+
+var (
+	errMissingID       = errors.New("uptime check ID is required")
+	errMissingName     = errors.New("uptime check name is required")
+	errInvalidType     = errors.New("invalid check type, expected http, tcp or ping")
+	errMissingURL      = errors.New("http checks require --url")
+	errInvalidURL      = errors.New("invalid URL, expected an http or https URL")
+	errMissingHost     = errors.New("tcp and ping checks require --host")
+	errInvalidPort     = errors.New("tcp checks require a --port between 1 and 65535")
+	errInvalidInterval = errors.New("check interval must be at least 1m")
+	errNoChanges       = errors.New("at least one change is required for update")
+	errTypeChange      = errors.New("the type of an uptime check cannot be changed")
+	errFieldForType    = errors.New("field does not apply to the check type")
+)
+
+// CheckSpec is the desired state of an uptime check, given as flags or as a
+// YAML file:
+//
+//	name: api health
+//	type: http
+//	url: https://api.example.com/health
+//	interval: 5m
+//	locations: [na, emea]
+//
+// Empty fields are left unchanged by update.
+type CheckSpec struct {
+	Name      string   `yaml:"name"`
+	Type      string   `yaml:"type"`
+	URL       string   `yaml:"url"`
+	Host      string   `yaml:"host"`
+	Port      int      `yaml:"port"`
+	Interval  string   `yaml:"interval"`
+	Locations []string `yaml:"locations"`
+}
+
+// Options represents the command line options for the uptime command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	ID                 string
+	Type               string
+	Spec               CheckSpec
+	IfNotExists        bool
+	AutoApprove        bool
+	JSON               bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{}
+}
+
+// LoadSpec reads the check spec from a YAML file. Flags set afterwards take
+// precedence over the file.
+func (o *Options) LoadSpec(path string) error {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(content, &o.Spec); err != nil {
+		return fmt.Errorf("error while unmarshaling %s: %w", path, err)
+	}
+	return nil
+}
+
+func validType(checkType string) bool {
+	return checkType == TypeHTTP || checkType == TypeTCP || checkType == TypePing
+}
+
+// interval parses the interval of the spec, zero if it is not set
+func (s *CheckSpec) interval() (time.Duration, error) {
+	if strings.TrimSpace(s.Interval) == "" {
+		return 0, nil
+	}
+	d, err := shared.ParseDuration(s.Interval)
+	if err != nil {
+		return 0, err
+	}
+	if d < time.Minute {
+		return 0, fmt.Errorf("%w: %s", errInvalidInterval, s.Interval)
+	}
+	return d, nil
+}
+
+// validateFields checks the fields that are set, whatever the operation
+func (s *CheckSpec) validateFields() error {
+	if s.URL != "" {
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s", errInvalidURL, s.URL)
+		}
+	}
+	if s.Port < 0 || s.Port > 65535 {
+		return errInvalidPort
+	}
+	_, err := s.interval()
+	return err
+}
+
+// validateForType rejects the fields that checks of the type do not use
+func (s *CheckSpec) validateForType(checkType string) error {
+	switch {
+	case checkType == TypeHTTP && (s.Host != "" || s.Port != 0):
+		return fmt.Errorf("%w: http checks use --url, not --host or --port", errFieldForType)
+	case checkType != TypeHTTP && s.URL != "":
+		return fmt.Errorf("%w: %s checks use --host, not --url", errFieldForType, checkType)
+	case checkType == TypePing && s.Port != 0:
+		return fmt.Errorf("%w: ping checks have no --port", errFieldForType)
+	}
+	return nil
+}
+
+// ValidateForList validates options for list operation
+func (o *Options) ValidateForList() error {
+	o.Type = strings.ToLower(strings.TrimSpace(o.Type))
+	if o.Type != "" && !validType(o.Type) {
+		return fmt.Errorf("%w: %s", errInvalidType, o.Type)
+	}
+	return nil
+}
+
+// ValidateForGet validates options for get, delete, pause and resume operations
+func (o *Options) ValidateForGet() error {
+	if strings.TrimSpace(o.ID) == "" {
+		return errMissingID
+	}
+	return nil
+}
+
+// ValidateForCreate validates options for create operation, including the
+// fields every check type requires
+func (o *Options) ValidateForCreate() error {
+	spec := &o.Spec
+	spec.Type = strings.ToLower(strings.TrimSpace(spec.Type))
+	if !validType(spec.Type) {
+		return fmt.Errorf("%w: %s", errInvalidType, spec.Type)
+	}
+	if strings.TrimSpace(spec.Name) == "" {
+		return errMissingName
+	}
+
+	switch spec.Type {
+	case TypeHTTP:
+		if spec.URL == "" {
+			return errMissingURL
+		}
+	case TypeTCP:
+		if spec.Host == "" {
+			return errMissingHost
+		}
+		if spec.Port == 0 {
+			return errInvalidPort
+		}
+	case TypePing:
+		if spec.Host == "" {
+			return errMissingHost
+		}
+	}
+
+	if err := spec.validateForType(spec.Type); err != nil {
+		return err
+	}
+	return spec.validateFields()
+}
+
+// ValidateForUpdate validates options for update operation. The type of a
+// check cannot be changed, the fields are checked against it by the update.
+func (o *Options) ValidateForUpdate() error {
+	if err := o.ValidateForGet(); err != nil {
+		return err
+	}
+
+	spec := o.Spec
+	if spec.Name == "" && spec.URL == "" && spec.Host == "" && spec.Port == 0 && spec.Interval == "" && len(spec.Locations) == 0 {
+		return errNoChanges
+	}
+
+	return spec.validateFields()
+}
@@ -0,0 +1,82 @@
+package uptime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateForCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    CheckSpec
+		wantErr error
+	}{
+		{"http", CheckSpec{Type: "HTTP", Name: "api", URL: "https://api.example.com/health"}, nil},
+		{"http without url", CheckSpec{Type: TypeHTTP, Name: "api"}, errMissingURL},
+		{"http with invalid url", CheckSpec{Type: TypeHTTP, Name: "api", URL: "ftp://example.com"}, errInvalidURL},
+		{"tcp", CheckSpec{Type: TypeTCP, Name: "db", Host: "db.example.com", Port: 5432}, nil},
+		{"tcp without port", CheckSpec{Type: TypeTCP, Name: "db", Host: "db.example.com"}, errInvalidPort},
+		{"tcp with invalid port", CheckSpec{Type: TypeTCP, Name: "db", Host: "db.example.com", Port: 70000}, errInvalidPort},
+		{"ping", CheckSpec{Type: TypePing, Name: "gw", Host: "10.0.0.1", Interval: "1m"}, nil},
+		{"ping without host", CheckSpec{Type: TypePing, Name: "gw"}, errMissingHost},
+		{"ping with port", CheckSpec{Type: TypePing, Name: "gw", Host: "10.0.0.1", Port: 22}, errFieldForType},
+		{"tcp with url", CheckSpec{Type: TypeTCP, Name: "db", Host: "db.example.com", Port: 5432, URL: "https://db.example.com"}, errFieldForType},
+		{"http with host", CheckSpec{Type: TypeHTTP, Name: "api", URL: "https://api.example.com", Host: "api.example.com"}, errFieldForType},
+		{"short interval", CheckSpec{Type: TypePing, Name: "gw", Host: "10.0.0.1", Interval: "30s"}, errInvalidInterval},
+		{"missing name", CheckSpec{Type: TypePing, Host: "10.0.0.1"}, errMissingName},
+		{"unknown type", CheckSpec{Type: "dns", Name: "dns"}, errInvalidType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			opts.Spec = tt.spec
+			err := opts.ValidateForCreate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateForUpdate(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingID, opts.ValidateForUpdate())
+
+	opts.ID = "c-1"
+	require.Equal(t, errNoChanges, opts.ValidateForUpdate())
+
+	opts.Spec.Interval = "15m"
+	require.NoError(t, opts.ValidateForUpdate())
+
+	opts.Spec.URL = "not a url"
+	require.ErrorIs(t, opts.ValidateForUpdate(), errInvalidURL)
+}
+
+func TestLoadSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "check.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`name: api health
+type: http
+url: https://api.example.com/health
+interval: 10m
+locations: [na, emea]
+`), 0o600))
+
+	opts := NewOptions()
+	require.NoError(t, opts.LoadSpec(path))
+	require.Equal(t, CheckSpec{
+		Name:      "api health",
+		Type:      TypeHTTP,
+		URL:       "https://api.example.com/health",
+		Interval:  "10m",
+		Locations: []string{"na", "emea"},
+	}, opts.Spec)
+	require.NoError(t, opts.ValidateForCreate())
+
+	require.Error(t, opts.LoadSpec(filepath.Join(t.TempDir(), "missing.yaml")))
+}
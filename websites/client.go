@@ -0,0 +1,232 @@
+// Package websites provides a client for the availability and response times of
+// websites monitored by Digital Experience Monitoring (DEM) in the SWO API.
+package websites
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultPageSize for retrieving list of websites
+	DefaultPageSize = 100
+)
+
+var (
+	// ErrInvalidAPIResponse indicates a non-2xx status code was received from the API
+	ErrInvalidAPIResponse = errors.New("received non-2xx status code")
+	// ErrNoContent indicates an empty response body was received from the API
+	ErrNoContent = errors.New("no content")
+)
+
+// Client is a websites client
+type Client struct {
+	opts       *Options
+	httpClient http.Client
+	output     *os.File
+}
+
+// Percentiles are response times in milliseconds
+type Percentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+}
+
+// Website is a monitored website with its availability in percent and response
+// times over the requested time range
+type Website struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	URL          string       `json:"url"`
+	Status       string       `json:"status"`
+	Availability *float64     `json:"availability,omitempty"`
+	ResponseTime *Percentiles `json:"responseTime,omitempty"`
+}
+
+// Probe is the result of a single check of a website from one location
+type Probe struct {
+	Location     string  `json:"location"`
+	Time         string  `json:"time"`
+	Success      bool    `json:"success"`
+	StatusCode   int     `json:"statusCode,omitempty"`
+	Error        string  `json:"error,omitempty"`
+	ResponseTime float64 `json:"responseTimeMs"`
+}
+
+// WebsiteStatus is a website with the recent probe results
+type WebsiteStatus struct {
+	Website
+	Probes []Probe `json:"probes"`
+}
+
+type pageInfo struct {
+	PrevPage string `json:"prevPage"`
+	NextPage string `json:"nextPage"`
+}
+
+type listWebsitesResponse struct {
+	Websites []Website `json:"websites"`
+	pageInfo `json:"pageInfo"`
+}
+
+// NewClient creates a new websites client
+func NewClient(opts *Options) (*Client, error) {
+	// Configure logging based on verbose flag
+	shared.SetupLogger(opts.Verbose)
+
+	return &Client{
+		httpClient: *http.DefaultClient,
+		opts:       opts,
+		output:     os.Stdout,
+	}, nil
+}
+
+// timeParams returns the time range of the options relative to now
+func (c *Client) timeParams() (url.Values, error) {
+	minTime, maxTime, err := c.opts.TimeRange(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	if minTime != "" {
+		params.Add("startTime", minTime)
+	}
+	if maxTime != "" {
+		params.Add("endTime", maxTime)
+	}
+	return params, nil
+}
+
+func (c *Client) prepareListRequest(ctx context.Context, nextPage string) (*http.Request, error) {
+	params, err := c.timeParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Add("pageSize", strconv.Itoa(DefaultPageSize))
+
+	return shared.NewGetRequest(ctx, c.opts.BaseOptions, params, nextPage, "v1/dem/websites")
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	slog.Debug("Sending HTTP request", "method", req.Method, "url", req.URL.String()) //nolint:gosec
+
+	response, err := c.httpClient.Do(req) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("error while sending http request to SWO: %w", err)
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			slog.Error("Could not close https body", "error", err)
+		}
+	}()
+
+	slog.Debug("Response status", "status_code", response.StatusCode, "status", response.Status)
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading http response body from SWO: %w", err)
+	}
+
+	slog.Debug("Response body", "length_bytes", len(content))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d, response body: %s", ErrInvalidAPIResponse, response.StatusCode, string(content))
+	}
+
+	if len(content) == 0 {
+		return nil, ErrNoContent
+	}
+
+	return content, nil
+}
+
+// fetchWebsites retrieves the websites of all pages
+func (c *Client) fetchWebsites(ctx context.Context) ([]Website, error) {
+	websites := []Website{}
+	var nextPage string
+
+	for {
+		request, err := c.prepareListRequest(ctx, nextPage)
+		if err != nil {
+			return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+		}
+
+		content, err := c.doRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		var response listWebsitesResponse
+		if err := json.Unmarshal(content, &response); err != nil {
+			return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+		}
+		websites = append(websites, response.Websites...)
+
+		if response.NextPage == "" {
+			return websites, nil
+		}
+		nextPage = response.NextPage
+	}
+}
+
+func (c *Client) fetchStatus(ctx context.Context) (*WebsiteStatus, error) {
+	params, err := c.timeParams()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := shared.NewGetRequest(ctx, c.opts.BaseOptions, params, "", "v1/dem/websites", c.opts.ID, "status")
+	if err != nil {
+		return nil, fmt.Errorf("error while preparing http request to SWO: %w", err)
+	}
+
+	content, err := c.doRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var status WebsiteStatus
+	if err := json.Unmarshal(content, &status); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling http response body from SWO: %w", err)
+	}
+
+	return &status, nil
+}
+
+// ListWebsites displays the monitored websites, refreshing them with --watch
+func (c *Client) ListWebsites(ctx context.Context) error {
+	return c.watch(ctx, func(w io.Writer) error {
+		websites, err := c.fetchWebsites(ctx)
+		if err != nil {
+			return err
+		}
+		return c.printWebsites(w, websites)
+	})
+}
+
+// Status displays the availability, response times and failing probes of a
+// website, refreshing them with --watch
+func (c *Client) Status(ctx context.Context) error {
+	return c.watch(ctx, func(w io.Writer) error {
+		status, err := c.fetchStatus(ctx)
+		if err != nil {
+			return err
+		}
+		return c.printStatus(w, status)
+	})
+}
@@ -0,0 +1,238 @@
+package websites
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+var testWebsites = []Website{
+	{
+		ID:           "w-1",
+		Name:         "Shop",
+		URL:          "https://shop.example.com",
+		Status:       "UP",
+		Availability: floatPtr(99.5234),
+		ResponseTime: &Percentiles{P50: 120, P90: 310, P95: 420.4, P99: 980},
+	},
+	{
+		ID:     "w-2",
+		Name:   "Docs",
+		URL:    "https://docs.example.com",
+		Status: "DOWN",
+	},
+}
+
+// newWebsiteServer serves the test websites with one website per page and
+// counts the requests
+func newWebsiteServer(t *testing.T, status *WebsiteStatus) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		require.Equal(t, "GET", r.Method)
+		requests.Add(1)
+
+		switch r.URL.Path {
+		case "/v1/dem/websites":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			response := listWebsitesResponse{Websites: testWebsites[page : page+1]}
+			if page+1 < len(testWebsites) {
+				response.NextPage = "/v1/dem/websites?page=" + strconv.Itoa(page+1)
+			}
+			testutil.WriteJSON(t, w, response)
+		case "/v1/dem/websites/w-1/status":
+			require.NotEmpty(t, r.URL.Query().Get("startTime"))
+			testutil.WriteJSON(t, w, status)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestListWebsites(t *testing.T) {
+	server, _ := newWebsiteServer(t, nil)
+
+	opts := NewOptions()
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.ListWebsites(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"NAME", "STATUS", "AVAILABILITY", "P50", "P95", "ID"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"Shop", "UP", "99.52%", "120ms", "420ms", "w-1"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"Docs", "DOWN", "-", "-", "-", "w-2"}, strings.Fields(lines[2]))
+}
+
+func TestListWebsitesJSON(t *testing.T) {
+	server, _ := newWebsiteServer(t, nil)
+
+	opts := NewOptions()
+	opts.JSON = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.ListWebsites(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(testutil.ReadOutput(t, client.output)), "\n")
+	require.Len(t, lines, 2)
+
+	var website Website
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &website))
+	require.Equal(t, testWebsites[0], website)
+}
+
+func TestStatus(t *testing.T) {
+	status := &WebsiteStatus{
+		Website: testWebsites[0],
+		Probes: []Probe{
+			{Location: "Frankfurt", Time: "2024-05-13T10:00:00Z", Success: false, StatusCode: 503},
+			{Location: "Frankfurt", Time: "2024-05-13T10:05:00Z", Success: false, Error: "connection timed out"},
+			{Location: "Oregon", Time: "2024-05-13T10:01:00Z", Success: false, StatusCode: 500},
+			{Location: "Sydney", Time: "2024-05-13T10:06:00Z", Success: true, StatusCode: 200},
+		},
+	}
+	server, _ := newWebsiteServer(t, status)
+
+	opts := NewOptions()
+	opts.ID = "w-1"
+	opts.MinTime = "1 day ago"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.Status(context.Background()))
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "Website: Shop (w-1)\n")
+	require.Contains(t, output, "Status: UP\n")
+	require.Contains(t, output, "Availability: 99.52%\n")
+	require.Contains(t, output, "Response time: p50 120ms, p90 310ms, p95 420ms, p99 980ms\n")
+	require.Contains(t, output, "Last failing probes:\n")
+
+	// Only the newest failure of every location, newest first
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	probes := lines[len(lines)-2:]
+	require.True(t, strings.HasPrefix(strings.TrimSpace(probes[0]), "Frankfurt"))
+	require.Contains(t, probes[0], "connection timed out")
+	require.True(t, strings.HasPrefix(strings.TrimSpace(probes[1]), "Oregon"))
+	require.Contains(t, probes[1], "500")
+	require.NotContains(t, output, "Sydney")
+}
+
+func TestStatusWithoutFailures(t *testing.T) {
+	status := &WebsiteStatus{Website: testWebsites[1]}
+	status.ID = "w-1"
+	server, _ := newWebsiteServer(t, status)
+
+	opts := NewOptions()
+	opts.ID = "w-1"
+	opts.MinTime = "1 day ago"
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.NoError(t, client.Status(context.Background()))
+
+	output := testutil.ReadOutput(t, client.output)
+	require.Contains(t, output, "Availability: -\n")
+	require.Contains(t, output, "Response time: -\n")
+	require.Contains(t, output, "Last failing probes: none\n")
+}
+
+func TestFailedProbesLimit(t *testing.T) {
+	var probes []Probe
+	for i := 0; i < 8; i++ {
+		probes = append(probes, Probe{Location: "loc-" + strconv.Itoa(i), Time: "2024-05-13T10:0" + strconv.Itoa(i) + ":00Z"})
+	}
+
+	failed := failedProbes(probes)
+	require.Len(t, failed, maxFailedProbes)
+	require.Equal(t, "loc-7", failed[0].Location)
+	require.Equal(t, "loc-3", failed[maxFailedProbes-1].Location)
+}
+
+func TestFailedProbesOrder(t *testing.T) {
+	failed := failedProbes([]Probe{
+		{Location: "Frankfurt", Time: "2024-05-13T12:00:00+02:00"},
+		{Location: "Oregon", Time: "2024-05-13T10:00:00.5Z"},
+		{Location: "Sydney", Time: "2024-05-13T10:00:00Z"},
+		{Location: "Tokyo", Time: "not a time"},
+	})
+
+	var locations []string
+	for _, probe := range failed {
+		locations = append(locations, probe.Location)
+	}
+	require.Equal(t, []string{"Oregon", "Frankfurt", "Sydney", "Tokyo"}, locations)
+}
+
+func TestWatchWebsites(t *testing.T) {
+	server, requests := newWebsiteServer(t, nil)
+
+	opts := NewOptions()
+	opts.Watch = true
+	opts.Interval = 10 * time.Millisecond
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- client.ListWebsites(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	// Every refresh lists both pages again
+	require.GreaterOrEqual(t, requests.Load(), int32(4))
+
+	output := testutil.ReadOutput(t, client.output)
+	require.NotContains(t, output, clearScreen)
+	require.Contains(t, output, "refreshing every 10ms")
+	require.Greater(t, strings.Count(output, "NAME"), 1)
+}
+
+func TestWatchFailsOnFirstRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("unauthorized"))
+	}))
+	t.Cleanup(server.Close)
+
+	opts := NewOptions()
+	opts.Watch = true
+	opts.Token = "test-token"
+	opts.APIURL = server.URL
+	client, err := NewClient(opts)
+	require.NoError(t, err)
+	client.output = testutil.TempFile(t)
+	require.ErrorIs(t, client.ListWebsites(context.Background()), ErrInvalidAPIResponse)
+}
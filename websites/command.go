@@ -0,0 +1,69 @@
+package websites
+
+import (
+	cli "github.com/urfave/cli/v2"
+)
+
+// watchFlags are the flags to refresh the output, e.g. on a NOC wall terminal
+func watchFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "watch",
+			Aliases: []string{"w"},
+			Usage:   "Keep refreshing a compact view until interrupted",
+		},
+		&cli.DurationFlag{
+			Name:  "interval",
+			Usage: "How often to refresh in watch mode",
+			Value: DefaultWatchInterval,
+		},
+		&cli.BoolFlag{
+			Name:    "json",
+			Aliases: []string{"j"},
+			Usage:   "Output in JSON format",
+		},
+	}
+}
+
+// NewWebsitesCommand creates the websites command
+func NewWebsitesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "websites",
+		Usage: "Show availability and response times of websites monitored by DEM",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List websites with their status, availability and response times",
+				Action: runList,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "min-time",
+						Usage: "Start of the time range for availability and response times",
+						Value: "1 day ago",
+					},
+					&cli.StringFlag{
+						Name:  "max-time",
+						Usage: "End of the time range for availability and response times",
+					},
+				}, watchFlags()...),
+			},
+			{
+				Name:      "status",
+				Usage:     "Show availability, response time percentiles and the last failing probe locations of a website",
+				ArgsUsage: "ID",
+				Action:    runStatus,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "min-time",
+						Usage: "Start of the time range for availability, response times and probes",
+						Value: "1 day ago",
+					},
+					&cli.StringFlag{
+						Name:  "max-time",
+						Usage: "End of the time range for availability, response times and probes",
+					},
+				}, watchFlags()...),
+			},
+		},
+	}
+}
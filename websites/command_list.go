@@ -0,0 +1,41 @@
+package websites
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/solarwinds/swo-cli/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+func newWebsiteOptions(ctx *cli.Context) *Options {
+	opts := NewOptions()
+	opts.MinTime = ctx.String("min-time")
+	opts.MaxTime = ctx.String("max-time")
+	opts.Watch = ctx.Bool("watch")
+	opts.Interval = ctx.Duration("interval")
+	opts.JSON = ctx.Bool("json")
+	opts.Verbose = ctx.Bool(config.VerboseContextKey)
+	opts.Token = ctx.String(config.TokenContextKey)
+	opts.APIURL = ctx.String(config.APIURLContextKey)
+	return opts
+}
+
+func runList(ctx *cli.Context) error {
+	opts := newWebsiteOptions(ctx)
+	if err := opts.ValidateForList(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return client.ListWebsites(runCtx)
+}
@@ -0,0 +1,28 @@
+package websites
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+func runStatus(ctx *cli.Context) error {
+	opts := newWebsiteOptions(ctx)
+	opts.ID = ctx.Args().First()
+	if err := opts.ValidateForStatus(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		return err
+	}
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return client.Status(runCtx)
+}
@@ -0,0 +1,62 @@
+package websites
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+const (
+	// DefaultWatchInterval is how often --watch refreshes the status
+	DefaultWatchInterval = 30 * time.Second
+)
+
+var (
+	errMissingID       = errors.New("website ID is required")
+	errInvalidInterval = errors.New("watch interval must be positive")
+)
+
+// Options represents the command line options for the websites command
+type Options struct {
+	shared.BaseOptions // Embedded base options (Verbose, Token, APIURL)
+	ID                 string
+	MinTime            string
+	MaxTime            string
+	Watch              bool
+	Interval           time.Duration
+	JSON               bool
+}
+
+// NewOptions creates a new Options instance
+func NewOptions() *Options {
+	return &Options{
+		Interval: DefaultWatchInterval,
+	}
+}
+
+// TimeRange parses the time flags into RFC3339 timestamps relative to now.
+// Watch mode calls it on every refresh, so relative times move with the clock.
+func (o *Options) TimeRange(now time.Time) (string, string, error) {
+	return shared.ParseTimeRange(o.MinTime, o.MaxTime, now)
+}
+
+// ValidateForList validates options for list operation
+func (o *Options) ValidateForList() error {
+	if _, _, err := o.TimeRange(time.Now()); err != nil {
+		return err
+	}
+	if o.Watch && o.Interval <= 0 {
+		return errInvalidInterval
+	}
+	return nil
+}
+
+// ValidateForStatus validates options for status operation
+func (o *Options) ValidateForStatus() error {
+	if strings.TrimSpace(o.ID) == "" {
+		return errMissingID
+	}
+	return o.ValidateForList()
+}
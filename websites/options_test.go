@@ -0,0 +1,56 @@
+package websites
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeRange(t *testing.T) {
+	now := time.Date(2024, 5, 13, 12, 0, 0, 0, time.UTC)
+
+	opts := NewOptions()
+	opts.MinTime = "1 day ago"
+	minTime, maxTime, err := opts.TimeRange(now)
+	require.NoError(t, err)
+	require.Equal(t, "2024-05-12T12:00:00Z", minTime)
+	require.Empty(t, maxTime)
+
+	// Relative times move with the clock on every refresh
+	minTime, _, err = opts.TimeRange(now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, "2024-05-12T13:00:00Z", minTime)
+
+	opts.MaxTime = "2 days ago"
+	_, _, err = opts.TimeRange(now)
+	require.Equal(t, shared.ErrInvalidTimeRange, err)
+
+	opts.MaxTime = "not a time"
+	_, _, err = opts.TimeRange(now)
+	require.ErrorIs(t, err, shared.ErrMaxTimeFlag)
+}
+
+func TestValidateForList(t *testing.T) {
+	opts := NewOptions()
+	require.NoError(t, opts.ValidateForList())
+
+	opts.Watch = true
+	require.NoError(t, opts.ValidateForList())
+
+	opts.Interval = 0
+	require.Equal(t, errInvalidInterval, opts.ValidateForList())
+
+	opts.Interval = DefaultWatchInterval
+	opts.MinTime = "what?"
+	require.ErrorIs(t, opts.ValidateForList(), shared.ErrMinTimeFlag)
+}
+
+func TestValidateForStatus(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errMissingID, opts.ValidateForStatus())
+
+	opts.ID = "w-1"
+	require.NoError(t, opts.ValidateForStatus())
+}
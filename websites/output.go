@@ -0,0 +1,144 @@
+package websites
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// maxFailedProbes is how many of the last failing probe locations are shown
+const maxFailedProbes = 5
+
+func formatAvailability(availability *float64) string {
+	if availability == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*availability, 'f', 2, 64) + "%"
+}
+
+func formatMillis(ms float64) string {
+	return strconv.FormatFloat(ms, 'f', 0, 64) + "ms"
+}
+
+func formatPercentile(responseTime *Percentiles, pick func(Percentiles) float64) string {
+	if responseTime == nil {
+		return "-"
+	}
+	return formatMillis(pick(*responseTime))
+}
+
+func formatProbeTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func probeTime(probe *Probe) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, probe.Time)
+	return t
+}
+
+// failedProbes returns the most recent failing probe of every location, newest first
+func failedProbes(probes []Probe) []Probe {
+	failed := make([]Probe, 0, len(probes))
+	for _, probe := range probes {
+		if !probe.Success {
+			failed = append(failed, probe)
+		}
+	}
+	// Times may differ in zone and precision, so they are compared parsed.
+	// Unparseable times are zero and sort last.
+	sort.SliceStable(failed, func(i, j int) bool {
+		return probeTime(&failed[i]).After(probeTime(&failed[j]))
+	})
+
+	seen := make(map[string]bool)
+	result := make([]Probe, 0, maxFailedProbes)
+	for _, probe := range failed {
+		if seen[probe.Location] {
+			continue
+		}
+		seen[probe.Location] = true
+		result = append(result, probe)
+		if len(result) == maxFailedProbes {
+			break
+		}
+	}
+	return result
+}
+
+func (c *Client) printWebsites(w io.Writer, websites []Website) error {
+	if c.opts.JSON {
+		for _, website := range websites {
+			jsonData, err := json.Marshal(website)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(w, string(jsonData))
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tSTATUS\tAVAILABILITY\tP50\tP95\tID")
+	for _, website := range websites {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", website.Name, website.Status,
+			formatAvailability(website.Availability),
+			formatPercentile(website.ResponseTime, func(p Percentiles) float64 { return p.P50 }),
+			formatPercentile(website.ResponseTime, func(p Percentiles) float64 { return p.P95 }),
+			website.ID)
+	}
+	return tw.Flush()
+}
+
+func (c *Client) printStatus(w io.Writer, status *WebsiteStatus) error {
+	if c.opts.JSON {
+		jsonData, err := json.Marshal(status)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(w, string(jsonData))
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Website: %s (%s)\n", status.Name, status.ID)
+	if status.URL != "" {
+		_, _ = fmt.Fprintf(w, "URL: %s\n", status.URL)
+	}
+	_, _ = fmt.Fprintf(w, "Status: %s\n", status.Status)
+	_, _ = fmt.Fprintf(w, "Availability: %s\n", formatAvailability(status.Availability))
+	if rt := status.ResponseTime; rt != nil {
+		_, _ = fmt.Fprintf(w, "Response time: p50 %s, p90 %s, p95 %s, p99 %s\n",
+			formatMillis(rt.P50), formatMillis(rt.P90), formatMillis(rt.P95), formatMillis(rt.P99))
+	} else {
+		_, _ = fmt.Fprintln(w, "Response time: -")
+	}
+
+	failed := failedProbes(status.Probes)
+	if len(failed) == 0 {
+		_, _ = fmt.Fprintln(w, "Last failing probes: none")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, "Last failing probes:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  LOCATION\tTIME\tSTATUS\tERROR")
+	for _, probe := range failed {
+		code := "-"
+		if probe.StatusCode != 0 {
+			code = strconv.Itoa(probe.StatusCode)
+		}
+		errorMessage := probe.Error
+		if errorMessage == "" {
+			errorMessage = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", probe.Location, formatProbeTime(probe.Time), code, errorMessage)
+	}
+	return tw.Flush()
+}
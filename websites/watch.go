@@ -0,0 +1,62 @@
+package websites
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/solarwinds/swo-cli/shared"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// watch renders once, or with --watch every interval until the context is
+// cancelled. Every refresh is rendered into a buffer first, so a terminal is
+// redrawn at once instead of flickering line by line.
+func (c *Client) watch(ctx context.Context, render func(w io.Writer) error) error {
+	if !c.opts.Watch {
+		return render(c.output)
+	}
+
+	terminal := shared.IsTerminal(c.output)
+	refresh := func() error {
+		var buf bytes.Buffer
+		if err := render(&buf); err != nil {
+			return err
+		}
+
+		if terminal {
+			_, _ = fmt.Fprint(c.output, clearScreen)
+		}
+		_, _ = c.output.Write(buf.Bytes())
+		if !c.opts.JSON {
+			_, _ = fmt.Fprintf(c.output, "\nUpdated at %s, refreshing every %s\n",
+				time.Now().Format(time.TimeOnly), shared.FormatDuration(c.opts.Interval))
+		}
+		return nil
+	}
+
+	// Fail early if the first request is rejected, e.g. because of a bad token
+	if err := refresh(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.opts.Interval):
+		}
+
+		if err := refresh(); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			slog.Error("Failed to refresh websites", "error", err)
+		}
+	}
+}